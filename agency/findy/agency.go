@@ -2,6 +2,8 @@ package findy
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
@@ -29,11 +31,41 @@ type Agency struct {
 	tlsPath        string
 	options        []grpc.DialOption
 
-	ctx        context.Context
-	connConfig *rpc.ClientCfg
-	conn       client.Conn
+	// ctx is the base context for the agency calls done on behalf of the agents
+	ctx    context.Context
+	cancel context.CancelFunc
+	// streams is the base context for the long-lived agency streams
+	streams      context.Context
+	closeStreams context.CancelFunc
+
+	// closed is set on shutdown, handling tracks the notifications being processed
+	closeMu  sync.Mutex
+	closed   bool
+	handling sync.WaitGroup
+	// drained is set when the shutdown has waited for the handling,
+	// notifications received after it cannot be stored anymore
+	drainMu sync.RWMutex
+	drained bool
+
+	callTimeout time.Duration
+	breaker     *breaker
+	connConfig  *rpc.ClientCfg
+	conn        client.Conn
 
 	userAsyncClient func(a *model.Agent) clientConn
+
+	keepaliveTimeout time.Duration
+	listeners        sync.Map
+
+	listenerConcurrency int
+	listenerStartRate   int
+	idleTimeout         time.Duration
+	// reconciling limits the agents whose jobs are reconciled in parallel, nil means no limit
+	reconciling chan struct{}
+
+	// owns tells the tenants this replica listens to when the vault is scaled
+	ownerMu sync.RWMutex
+	owns    func(tenantID string) bool
 }
 
 func (f *Agency) Init(
@@ -51,12 +83,27 @@ func (f *Agency) Init(
 	f.agencyInsecure = config.AgencyInsecure
 	f.tlsPath = config.AgencyCertPath
 
-	f.ctx = context.Background()
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	f.streams, f.closeStreams = context.WithCancel(f.ctx)
+	f.callTimeout = time.Duration(config.AgencyCallTimeout) * time.Second
+	f.keepaliveTimeout = time.Duration(config.AgencyKeepaliveTimeout) * time.Second
+	f.listenerConcurrency = config.AgencyListenerConcurrency
+	f.listenerStartRate = config.AgencyListenerStartRate
+	if f.listenerConcurrency > 0 {
+		f.reconciling = make(chan struct{}, f.listenerConcurrency)
+	}
+	f.idleTimeout = time.Duration(config.AgencyIdleDays) * 24 * time.Hour
+	f.breaker = newBreaker(config.AgencyBreakerThreshold, time.Duration(config.AgencyBreakerCooldown)*time.Second)
+
+	options := append([]grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(f.breaker.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(f.breaker.streamInterceptor),
+	}, f.options...)
 	if f.agencyInsecure && f.tlsPath == "" {
 		glog.Warning("Establishing insecure connection to agency")
-		f.connConfig = client.BuildInsecureClientConnBase(f.agencyHost, f.agencyPort, f.options)
+		f.connConfig = client.BuildInsecureClientConnBase(f.agencyHost, f.agencyPort, options)
 	} else {
-		f.connConfig = client.BuildClientConnBase(f.tlsPath, f.agencyHost, f.agencyPort, f.options)
+		f.connConfig = client.BuildClientConnBase(f.tlsPath, f.agencyHost, f.agencyPort, options)
 	}
 	// open connection without JWT token
 	// instead, token is set on each call
@@ -66,30 +113,86 @@ func (f *Agency) Init(
 	f.archiver = archiver
 	f.userAsyncClient = f.getUserAsyncClient
 
-	if config.AgencyMainSubscriber {
-		try.To(f.listenAdminHook())
-	} else {
-		glog.Warningln("DEV mode: Skipping subscribing to PSM hook.")
+	// with multiple replicas the listeners are started on Rebalance
+	if len(agents) > 0 {
+		go f.startListeners(agents)
+	}
+	go f.watchIdle()
+}
+
+// AddAgent makes sure that the agent is listened. It is called whenever
+// the agent accesses the vault and resumes the listener if it is suspended.
+func (f *Agency) AddAgent(agent *model.Agent) error {
+	if f.isClosed() {
+		return model.ErrClosed
+	}
+	f.listenerState(agent.TenantID).touch(time.Now().UTC())
+	if !f.owner(agent.TenantID) {
+		// the replica owning the tenant listens to it
+		return nil
 	}
+	return f.openListener(agent)
+}
+
+// Close stops the listener streams and the PSM hook. Notifications already being handled
+// are completed before the connection is closed. The agency does not deliver notifications again once
+// they are sent on the stream, so the ones received while closing are stored as dead letters and processed after restart.
+func (f *Agency) Close(ctx context.Context) (err error) {
+	f.closeMu.Lock()
+	f.closed = true
+	f.closeMu.Unlock()
+
+	glog.Infoln("Closing agency listeners")
+	f.closeStreams()
 
-	for _, a := range agents {
-		err := f.listenAgent(a)
-		if err != nil {
-			glog.Error(err)
+	if err = utils.Wait(ctx, &f.handling); err != nil {
+		glog.Errorf("Agency notifications were not handled before shutdown: %s", err.Error())
+	}
+	f.drainMu.Lock()
+	f.drained = true
+	f.drainMu.Unlock()
+
+	f.cancel()
+	if f.conn.ClientConn != nil {
+		if closeErr := f.conn.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
 	}
+	return err
 }
 
-func (f *Agency) AddAgent(agent *model.Agent) error {
-	return f.listenAgent(agent)
+func (f *Agency) isClosed() bool {
+	f.closeMu.Lock()
+	defer f.closeMu.Unlock()
+	return f.closed
+}
+
+// startHandling reserves a slot for handling a notification, it fails when the agency is closed
+func (f *Agency) startHandling() bool {
+	f.closeMu.Lock()
+	defer f.closeMu.Unlock()
+	if f.closed {
+		return false
+	}
+	f.handling.Add(1)
+	return true
+}
+
+func (f *Agency) Health() *model.Health {
+	return f.breaker.health()
 }
 
-func (f *Agency) Invite(a *model.Agent) (data *model.InvitationData, err error) {
+func (f *Agency) Invite(ctx context.Context, a *model.Agent) (data *model.InvitationData, err error) {
+	defer err2.Handle(&err, toAgencyError)
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
+
 	cmd := agency.NewAgentServiceClient(f.conn)
 	id := uuid.New().String()
 
 	res := try.To1(cmd.CreateInvitation(
-		f.ctx,
+		ctx,
 		&agency.InvitationBase{Label: a.Label, ID: id},
 		f.callOptions(a.RawJWT)...,
 	))
@@ -102,35 +205,55 @@ func (f *Agency) Invite(a *model.Agent) (data *model.InvitationData, err error)
 	return
 }
 
-func (f *Agency) Connect(a *model.Agent, strInvitation string) (id string, err error) {
-	defer err2.Handle(&err) // TODO: do not leak internal errors to client
+func (f *Agency) Connect(ctx context.Context, a *model.Agent, strInvitation string) (id string, err error) {
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
 
 	cmd := f.userSyncClient(a, "")
 
 	utils.LogMed().Infof("Adding connection with invitation %s for tenant %s", strInvitation, a.TenantID)
 
 	cmd.Label = a.Label
-	protocolID := try.To1(cmd.Connection(f.ctx, strInvitation))
+	protocolID := try.To1(cmd.Connection(ctx, strInvitation))
 
 	return protocolID.ID, err
 }
 
-func (f *Agency) SendMessage(a *model.Agent, connectionID, message string) (id string, err error) {
-	defer err2.Handle(&err) // TODO: do not leak internal errors to client
+func (f *Agency) SendMessage(ctx context.Context, a *model.Agent, connectionID, message string) (id string, err error) {
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
 
 	cmd := f.userSyncClient(a, connectionID)
 
-	protocolID := try.To1(cmd.BasicMessage(f.ctx, message))
+	protocolID := try.To1(cmd.BasicMessage(ctx, message))
+
+	// Add message to db, delivery status is updated when protocol completes
+	job := &model.JobInfo{
+		TenantID:     a.TenantID,
+		JobID:        protocolID.ID,
+		ConnectionID: connectionID,
+	}
+
+	try.To(f.vault.AddMessage(job, &model.Message{Message: message, SentByMe: true}))
 
 	return protocolID.ID, err
 }
 
-func (f *Agency) SendProofRequest(a *model.Agent, connectionID string, attributes []model.Attribute) (id string, err error) {
-	defer err2.Handle(&err) // TODO: do not leak internal errors to client
-
-	cmd := f.userSyncClient(a, connectionID)
+func (f *Agency) SendProofRequest(
+	ctx context.Context,
+	a *model.Agent,
+	connectionID string,
+	attributes []model.Attribute,
+	predicates []model.Predicate,
+) (id string, err error) {
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
 
 	// Create attributes for agency and those for vault db
+	restricted := false
 	proofAttrs := make([]*agency.Protocol_Proof_Attribute, len(attributes))
 	vaultAttrs := make([]*graph.ProofAttribute, len(attributes))
 	for i, attr := range attributes {
@@ -138,18 +261,62 @@ func (f *Agency) SendProofRequest(a *model.Agent, connectionID string, attribute
 			Name:      attr.Name,
 			CredDefID: attr.CredDefID,
 		}
+		restricted = restricted || len(attr.Restrictions) > 0
+		restrictions := attr.Restrictions
+		if restrictions == nil {
+			restrictions = make([]*graph.ProofRestriction, 0)
+		}
 		vaultAttrs[i] = &graph.ProofAttribute{
-			Name:      attr.Name,
-			CredDefID: attr.CredDefID,
+			Name:         attr.Name,
+			CredDefID:    attr.CredDefID,
+			Restrictions: restrictions,
 		}
 	}
 
-	proof := &agency.Protocol_Proof{
-		Attributes: proofAttrs,
+	vaultPreds := make([]*graph.ProofPredicate, len(predicates))
+	for i, pred := range predicates {
+		vaultPreds[i] = &graph.ProofPredicate{
+			Name:      pred.Name,
+			Operator:  pred.Operator,
+			Value:     int(pred.Value),
+			CredDefID: pred.CredDefID,
+		}
 	}
 
-	// Agency request
-	protocolID := try.To1(cmd.ReqProofWithAttrs(f.ctx, proof))
+	proof := &agency.Protocol_PresentProofMsg{
+		AttrFmt: &agency.Protocol_PresentProofMsg_Attributes{
+			Attributes: &agency.Protocol_Proof{
+				Attributes: proofAttrs,
+			},
+		},
+	}
+	if restricted {
+		// structured attribute format carries only cred def id, use indy format for the full restrictions
+		proof.AttrFmt = &agency.Protocol_PresentProofMsg_AttributesJSON{
+			AttributesJSON: try.To1(toAttributesJSON(attributes)),
+		}
+	}
+	if len(predicates) > 0 {
+		// structured predicate format does not carry restrictions, use indy format
+		proof.PredFmt = &agency.Protocol_PresentProofMsg_PredicatesJSON{
+			PredicatesJSON: try.To1(toPredicatesJSON(predicates)),
+		}
+	}
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
+
+	// Agency request, async pairwise client does not support predicates, start protocol directly
+	protocolID := try.To1(f.conn.DoStart(
+		ctx,
+		&agency.Protocol{
+			ConnectionID: connectionID,
+			TypeID:       agency.Protocol_PRESENT_PROOF,
+			Role:         agency.Protocol_INITIATOR,
+			StartMsg:     &agency.Protocol_PresentProof{PresentProof: proof},
+		},
+		f.callOptions(a.RawJWT)...,
+	))
 
 	// Add proof to db
 	job := &model.JobInfo{
@@ -161,6 +328,7 @@ func (f *Agency) SendProofRequest(a *model.Agent, connectionID string, attribute
 	vaultProof := &model.Proof{
 		Role:          graph.ProofRoleVerifier,
 		Attributes:    vaultAttrs,
+		Predicates:    vaultPreds,
 		InitiatedByUs: true,
 	}
 
@@ -169,13 +337,98 @@ func (f *Agency) SendProofRequest(a *model.Agent, connectionID string, attribute
 	return protocolID.ID, err
 }
 
+func (f *Agency) SendCredentialOffer(
+	ctx context.Context,
+	a *model.Agent,
+	connectionID, credDefID string,
+	attributes []*graph.CredentialValue,
+) (id string, err error) {
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
+
+	cmd := f.userSyncClient(a, connectionID)
+
+	// Create attributes for agency and those for vault db
+	issueAttrs := make([]*agency.Protocol_IssuingAttributes_Attribute, len(attributes))
+	vaultAttrs := make([]*graph.CredentialValue, len(attributes))
+	for i, attr := range attributes {
+		issueAttrs[i] = &agency.Protocol_IssuingAttributes_Attribute{
+			Name:  attr.Name,
+			Value: attr.Value,
+		}
+		vaultAttrs[i] = &graph.CredentialValue{
+			Name:  attr.Name,
+			Value: attr.Value,
+		}
+	}
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
+
+	// Agency request
+	protocolID := try.To1(cmd.IssueWithAttrs(
+		ctx,
+		credDefID,
+		&agency.Protocol_IssuingAttributes{Attributes: issueAttrs},
+	))
+
+	// Add credential to db
+	job := &model.JobInfo{
+		TenantID:     a.TenantID,
+		JobID:        protocolID.ID,
+		ConnectionID: connectionID,
+	}
+
+	vaultCredential := &model.Credential{
+		Role:          graph.CredentialRoleIssuer,
+		CredDefID:     credDefID,
+		Attributes:    vaultAttrs,
+		InitiatedByUs: true,
+	}
+
+	try.To1(f.vault.AddCredential(job, vaultCredential))
+
+	return protocolID.ID, err
+}
+
+func (f *Agency) PingConnection(ctx context.Context, a *model.Agent, connectionID string) (id string, err error) {
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
+
+	// async pairwise client does not support trust ping, start protocol directly
+	protocolID := try.To1(f.conn.DoStart(
+		ctx,
+		&agency.Protocol{
+			ConnectionID: connectionID,
+			TypeID:       agency.Protocol_TRUST_PING,
+			Role:         agency.Protocol_INITIATOR,
+		},
+		f.callOptions(a.RawJWT)...,
+	))
+
+	job := &model.JobInfo{
+		TenantID:     a.TenantID,
+		JobID:        protocolID.ID,
+		ConnectionID: connectionID,
+	}
+
+	try.To1(f.vault.AddPing(job, &model.Ping{InitiatedByUs: true}))
+
+	return protocolID.ID, err
+}
+
 func (f *Agency) resume(
+	ctx context.Context,
 	a *model.Agent,
 	job *model.JobInfo,
 	accept bool,
 	protocol agency.Protocol_Type,
 ) (err error) {
-	defer err2.Handle(&err) // TODO: do not leak internal errors to client
+	defer err2.Handle(&err, toAgencyError) // TODO: do not leak internal errors to client
+
+	ctx, cancel := f.callContext(ctx)
+	defer cancel()
 
 	cmd := f.userSyncClient(a, job.ConnectionID)
 	state := agency.ProtocolState_NACK
@@ -183,27 +436,59 @@ func (f *Agency) resume(
 		state = agency.ProtocolState_ACK
 	}
 
-	try.To1(cmd.Resume(f.ctx, job.JobID, protocol, state))
+	try.To1(cmd.Resume(ctx, job.JobID, protocol, state))
 
 	return
 }
 
-func (f *Agency) ResumeCredentialOffer(a *model.Agent, job *model.JobInfo, accept bool) (err error) {
+func (f *Agency) ResumeCredentialOffer(ctx context.Context, a *model.Agent, job *model.JobInfo, accept bool) (err error) {
+	return f.resumeCredentialOffer(ctx, a, job, nil, accept)
+}
+
+func (f *Agency) resumeCredentialOffer(
+	ctx context.Context,
+	a *model.Agent,
+	job *model.JobInfo,
+	credential *model.Credential,
+	accept bool,
+) (err error) {
 	defer err2.Handle(&err)
-	try.To(f.resume(a, job, accept, agency.Protocol_ISSUE_CREDENTIAL))
+	try.To(f.resume(ctx, a, job, accept, agency.Protocol_ISSUE_CREDENTIAL))
 
 	now := f.currentTimeMs()
-	return f.vault.UpdateCredential(job, nil, &model.CredentialUpdate{ApprovedMs: &now})
+	return f.vault.UpdateCredential(job, credential, &model.CredentialUpdate{ApprovedMs: &now})
 }
 
-func (f *Agency) ResumeProofRequest(a *model.Agent, job *model.JobInfo, accept bool) (err error) {
-	return f.resumeProofRequest(a, job, nil, accept)
+func (f *Agency) ResumeProofRequest(
+	ctx context.Context,
+	a *model.Agent,
+	job *model.JobInfo,
+	accept bool,
+) (err error) {
+	return f.resumeProofRequest(ctx, a, job, nil, accept)
 }
 
-func (f *Agency) resumeProofRequest(a *model.Agent, job *model.JobInfo, proof *model.Proof, accept bool) (err error) {
+func (f *Agency) resumeProofRequest(
+	ctx context.Context,
+	a *model.Agent,
+	job *model.JobInfo,
+	proof *model.Proof,
+	accept bool,
+) (err error) {
 	defer err2.Handle(&err)
-	try.To(f.resume(a, job, accept, agency.Protocol_PRESENT_PROOF))
+	try.To(f.resume(ctx, a, job, accept, agency.Protocol_PRESENT_PROOF))
 
 	now := f.currentTimeMs()
 	return f.vault.UpdateProof(job, proof, &model.ProofUpdate{ApprovedMs: &now})
 }
+
+func (f *Agency) CancelJob(ctx context.Context, a *model.Agent, job *model.JobInfo, protocol graph.ProtocolType) (err error) {
+	defer err2.Handle(&err, toAgencyError)
+
+	utils.LogMed().Infof("Cancel job %s (%s) for tenant %s", job.JobID, protocol, a.TenantID)
+
+	cmd := f.userClient(ctx, a)
+	try.To1(cmd.release(job.JobID, toAgencyProtocolType(protocol)))
+
+	return
+}
@@ -3,6 +3,7 @@ package findy
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/utils"
 	agency "github.com/findy-network/findy-common-go/grpc/agency/v1"
 	ops "github.com/findy-network/findy-common-go/grpc/ops/v1"
@@ -130,14 +132,14 @@ func (m *mockListener) AddConnection(_ *model.JobInfo, _ *model.Connection) erro
 	panic("Not implemented")
 }
 func (m *mockListener) AddMessage(_ *model.JobInfo, _ *model.Message) error {
-	panic("Not implemented")
+	return nil
 }
-func (m *mockListener) UpdateMessage(_ *model.JobInfo, _ *model.MessageUpdate) error {
+func (m *mockListener) UpdateMessage(_ *model.JobInfo, _ *model.Message, _ *model.MessageUpdate) error {
 	panic("Not implemented")
 }
 
 func (m *mockListener) AddCredential(_ *model.JobInfo, _ *model.Credential) (*dbModel.Job, error) {
-	panic("Not implemented")
+	return &dbModel.Job{}, nil
 }
 func (m *mockListener) UpdateCredential(_ *model.JobInfo, _ *model.Credential, update *model.CredentialUpdate) error {
 	m.credTS = *update.ApprovedMs
@@ -153,7 +155,23 @@ func (m *mockListener) UpdateProof(_ *model.JobInfo, _ *model.Proof, update *mod
 	return nil
 }
 
-func (m *mockListener) FailJob(_ *model.JobInfo) error {
+func (m *mockListener) AddPing(_ *model.JobInfo, _ *model.Ping) (*dbModel.Job, error) {
+	return &dbModel.Job{}, nil
+}
+
+func (m *mockListener) UpdatePing(_ *model.JobInfo, _ *model.Ping, _ *model.PingUpdate) error {
+	panic("Not implemented")
+}
+
+func (m *mockListener) FailJob(_ *model.JobInfo, _ *model.JobFailure) error {
+	panic("Not implemented")
+}
+
+func (m *mockListener) OpenJobs(_ string) ([]*dbModel.Job, error) {
+	return []*dbModel.Job{}, nil
+}
+
+func (m *mockListener) AddDeadLetter(_ *dbModel.DeadLetter) error {
 	panic("Not implemented")
 }
 
@@ -201,6 +219,9 @@ func TestInit(t *testing.T) {
 		&mockArchiver{},
 		&utils.Configuration{JWTKey: "mySuperSecretKeyLol", AgencyCertPath: tlsPath, AgencyAdminID: "admin-id", AgencyMainSubscriber: true},
 	)
+	if err := testAgency.ListenHook(context.Background()); err != nil {
+		t.Errorf("Unexpected error when listening to hook %s", err)
+	}
 	// Wait for a while that calls complete
 	time.Sleep(time.Millisecond * 100)
 	if mockAgencyServer.hookID == "" {
@@ -233,6 +254,9 @@ func TestInitInsecure(t *testing.T) {
 			AgencyInsecure:       true,
 		},
 	)
+	if err := testAgency.ListenHook(context.Background()); err != nil {
+		t.Errorf("Unexpected error when listening to hook %s", err)
+	}
 	// Wait for a while that calls complete
 	time.Sleep(time.Millisecond * 100)
 	if mockAgencyServer.hookID == "" {
@@ -251,7 +275,7 @@ func TestInitInsecure(t *testing.T) {
 }
 
 func TestInvite(t *testing.T) {
-	data, err := findy.Invite(agent)
+	data, err := findy.Invite(context.Background(), agent)
 	if err != nil {
 		t.Errorf("Encountered error on invite %v", err)
 	}
@@ -264,7 +288,7 @@ func TestInvite(t *testing.T) {
 }
 
 func TestConnect(t *testing.T) {
-	id, err := findy.Connect(agent, testInvitation)
+	id, err := findy.Connect(context.Background(), agent, testInvitation)
 	if err != nil {
 		t.Errorf("Encountered error on connect %v", err)
 	}
@@ -274,7 +298,7 @@ func TestConnect(t *testing.T) {
 }
 
 func TestSendMessage(t *testing.T) {
-	id, err := findy.SendMessage(agent, "id", "message")
+	id, err := findy.SendMessage(context.Background(), agent, "id", "message")
 	if err != nil {
 		t.Errorf("Encountered error on sending message %v", err)
 	}
@@ -284,7 +308,13 @@ func TestSendMessage(t *testing.T) {
 }
 
 func TestSendProofRequest(t *testing.T) {
-	id, err := findy.SendProofRequest(agent, "id", []model.Attribute{{Name: "name", CredDefID: "credDefID"}})
+	id, err := findy.SendProofRequest(
+		context.Background(),
+		agent,
+		"id",
+		[]model.Attribute{{Name: "name", CredDefID: "credDefID"}},
+		[]model.Predicate{{Name: "age", Operator: ">=", Value: 18, CredDefID: "credDefID"}},
+	)
 	if err != nil {
 		t.Errorf("Encountered error on sending proof request %v", err)
 	}
@@ -293,8 +323,28 @@ func TestSendProofRequest(t *testing.T) {
 	}
 }
 
+func TestSendCredentialOffer(t *testing.T) {
+	id, err := findy.SendCredentialOffer(context.Background(), agent, "id", "credDefID", []*graph.CredentialValue{{Name: "name", Value: "value"}})
+	if err != nil {
+		t.Errorf("Encountered error on sending credential offer %v", err)
+	}
+	if id != testID {
+		t.Errorf("Mismatch with id expecting %v, got %v", testID, id)
+	}
+}
+
+func TestPingConnection(t *testing.T) {
+	id, err := findy.PingConnection(context.Background(), agent, "id")
+	if err != nil {
+		t.Errorf("Encountered error on ping %v", err)
+	}
+	if id != testID {
+		t.Errorf("Mismatch with id expecting %v, got %v", testID, id)
+	}
+}
+
 func TestResumeCredentialOffer(t *testing.T) {
-	err := findy.ResumeCredentialOffer(agent, &model.JobInfo{}, true)
+	err := findy.ResumeCredentialOffer(context.Background(), agent, &model.JobInfo{}, true)
 	if err != nil {
 		t.Errorf("Encountered error on resume credential offer %v", err)
 	}
@@ -306,7 +356,7 @@ func TestResumeCredentialOffer(t *testing.T) {
 }
 
 func TestResumeProofRequest(t *testing.T) {
-	err := findy.ResumeProofRequest(agent, &model.JobInfo{}, true)
+	err := findy.ResumeProofRequest(context.Background(), agent, &model.JobInfo{}, true)
 	if err != nil {
 		t.Errorf("Encountered error on resume proof request %v", err)
 	}
@@ -316,3 +366,61 @@ func TestResumeProofRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestCancelJob(t *testing.T) {
+	err := findy.CancelJob(context.Background(), agent, &model.JobInfo{JobID: "job-id"}, graph.ProtocolTypeProof)
+	if err != nil {
+		t.Errorf("Encountered error on cancel job %v", err)
+	}
+}
+
+func TestCallCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := findy.PingConnection(ctx, agent, "id")
+	if !errors.Is(err, model.ErrCancelled) {
+		t.Errorf("Expected cancelled error, got %v", err)
+	}
+}
+
+func TestCallDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	_, err := findy.SendMessage(ctx, agent, "id", "message")
+	if !errors.Is(err, model.ErrTimeout) {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	testAgency := &Agency{options: dialOptions}
+	testAgency.Init(
+		&mockListener{},
+		[]*model.Agent{},
+		&mockArchiver{},
+		&utils.Configuration{JWTKey: "mySuperSecretKeyLol", AgencyCertPath: tlsPath},
+	)
+
+	if !testAgency.startHandling() {
+		t.Fatalf("Expected handling to be allowed before closing")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := testAgency.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected close to wait for handling, got %v", err)
+	}
+	testAgency.handling.Done()
+
+	if testAgency.startHandling() {
+		t.Errorf("Expected handling to be refused after closing")
+	}
+	if err := testAgency.AddAgent(&model.Agent{TenantID: "closed"}); !errors.Is(err, model.ErrClosed) {
+		t.Errorf("Expected error when adding agent after closing, got %v", err)
+	}
+	if testAgency.streams.Err() == nil {
+		t.Errorf("Expected streams to be closed")
+	}
+}
@@ -0,0 +1,159 @@
+package findy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// breaker stops calling the agency after consecutive connection failures.
+// After the cooldown a single trial call is let through: success closes the breaker
+// and failure opens it again. Calls that are cancelled or rejected by the agency tell nothing
+// about the connection, they neither close nor open the breaker and the next call is let through as the trial.
+// Notifications whose status cannot be fetched while the breaker is open are stored
+// as dead letters and retried once the agency is reachable again.
+type breaker struct {
+	sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    graph.BreakerState
+	failures int
+	openedAt time.Time
+	// trial is set while the half-open trial call is in progress
+	trial bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     graph.BreakerStateClosed,
+	}
+}
+
+// isConnectionFailure reports if the error means that agency could not be reached
+func isConnectionFailure(err error) bool {
+	if err == nil || errors.Is(err, model.ErrUnavailable) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	code := grpcStatus.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case graph.BreakerStateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		glog.Infoln("Agency breaker half-open, trying agency connection")
+		b.state = graph.BreakerStateHalfOpen
+		b.trial = true
+		return true
+	case graph.BreakerStateHalfOpen:
+		// single trial call at a time
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	case graph.BreakerStateClosed:
+	}
+	return true
+}
+
+func (b *breaker) record(err error) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.trial = false
+	if err == nil {
+		if b.state != graph.BreakerStateClosed {
+			glog.Infoln("Agency breaker closed, agency connection recovered")
+		}
+		b.state = graph.BreakerStateClosed
+		b.failures = 0
+		return
+	}
+	if !isConnectionFailure(err) {
+		return
+	}
+
+	b.failures++
+	if b.threshold <= 0 {
+		return
+	}
+	if b.state == graph.BreakerStateHalfOpen || b.failures >= b.threshold {
+		if b.state != graph.BreakerStateOpen {
+			glog.Warningf("Agency breaker open after %d failures: %s", b.failures, err.Error())
+		}
+		b.state = graph.BreakerStateOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *breaker) health() *model.Health {
+	b.Lock()
+	defer b.Unlock()
+
+	health := &model.Health{
+		Breaker:  b.state,
+		Failures: b.failures,
+	}
+	if b.state == graph.BreakerStateOpen {
+		health.RetryAt = b.openedAt.Add(b.cooldown)
+	}
+	return health
+}
+
+func (b *breaker) unaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if !b.allow() {
+		return model.ErrUnavailable
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.record(err)
+	return err
+}
+
+// streamInterceptor does not block the streams as the listeners reconnect with backoff,
+// but opening the stream tells the breaker if the agency is reachable
+func (b *breaker) streamInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	b.record(err)
+	return stream, err
+}
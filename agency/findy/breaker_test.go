@@ -0,0 +1,100 @@
+package findy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	const cooldown = time.Minute
+
+	now := time.Now()
+	b := newBreaker(2, cooldown)
+	b.now = func() time.Time { return now }
+
+	unavailable := grpcStatus.Error(codes.Unavailable, "connection refused")
+
+	// other errors do not open the breaker
+	b.record(errors.New("invalid argument"))
+	b.record(unavailable)
+	if !b.allow() || b.health().Breaker != graph.BreakerStateClosed {
+		t.Errorf("Breaker should be closed after single failure, got %+v", b.health())
+	}
+
+	b.record(unavailable)
+	health := b.health()
+	if b.allow() || health.Breaker != graph.BreakerStateOpen || health.Failures != 2 {
+		t.Errorf("Breaker should be open after threshold, got %+v", health)
+	}
+	if !health.RetryAt.Equal(now.Add(cooldown)) {
+		t.Errorf("Mismatch in retry time, expected: %v got: %v", now.Add(cooldown), health.RetryAt)
+	}
+
+	// single trial call after cooldown
+	now = now.Add(cooldown)
+	if !b.allow() || b.allow() {
+		t.Errorf("Breaker should allow single trial call after cooldown")
+	}
+	b.record(unavailable)
+	if b.allow() || b.health().Breaker != graph.BreakerStateOpen {
+		t.Errorf("Failed trial call should open the breaker, got %+v", b.health())
+	}
+
+	// cancelled or rejected trial call leaves the breaker half-open for the next trial
+	now = now.Add(cooldown)
+	for _, err := range []error{
+		grpcStatus.Error(codes.Canceled, "context canceled"),
+		context.Canceled,
+		grpcStatus.Error(codes.NotFound, "protocol not found"),
+	} {
+		if !b.allow() {
+			t.Errorf("Breaker should allow trial call after %v", err)
+		}
+		b.record(err)
+		if b.health().Breaker != graph.BreakerStateHalfOpen {
+			t.Errorf("Trial call with %v should not close the breaker, got %+v", err, b.health())
+		}
+	}
+
+	// trial call hitting its deadline opens the breaker again
+	if !b.allow() {
+		t.Errorf("Breaker should allow trial call")
+	}
+	b.record(context.DeadlineExceeded)
+	if b.allow() || b.health().Breaker != graph.BreakerStateOpen {
+		t.Errorf("Trial call exceeding deadline should open the breaker, got %+v", b.health())
+	}
+
+	now = now.Add(cooldown)
+	if !b.allow() {
+		t.Errorf("Breaker should allow trial call after cooldown")
+	}
+	b.record(nil)
+	if !b.allow() || b.health().Breaker != graph.BreakerStateClosed || b.health().Failures != 0 {
+		t.Errorf("Successful trial call should close the breaker, got %+v", b.health())
+	}
+}
+
+func TestReconnectDelay(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, baseReconnectDelay / 2, baseReconnectDelay},
+		{3, 4 * baseReconnectDelay, 8 * baseReconnectDelay},
+		{100, maxReconnectDelay / 2, maxReconnectDelay},
+	}
+	for _, tc := range tests {
+		for i := 0; i < 10; i++ {
+			if got := reconnectDelay(tc.attempt); got < tc.min || got > tc.max {
+				t.Errorf("Reconnect delay for attempt %d out of range [%v, %v]: %v", tc.attempt, tc.min, tc.max, got)
+			}
+		}
+	}
+}
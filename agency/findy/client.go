@@ -3,7 +3,9 @@ package findy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
 	"github.com/findy-network/findy-agent-vault/utils"
@@ -18,20 +20,51 @@ import (
 	"github.com/lainio/err2/try"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/oauth"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 type clientConn interface {
 	release(id string, protocolType agency.Protocol_Type) (pid *agency.ProtocolID, err error)
 	status(id string, protocolType agency.Protocol_Type) (pid *agency.ProtocolStatus, err error)
-	listen(id string) (ch chan *AgentStatus, err error)
-	psmHook() (ch chan *ops.AgencyStatus, err error)
+	listen(ctx context.Context, id string) (ch chan *AgentStatus, err error)
+	psmHook(ctx context.Context) (ch chan *ops.AgencyStatus, err error)
 }
 
 type Client struct {
 	*client.Conn
-	ctx   context.Context
-	cOpts []grpc.CallOption
+	ctx     context.Context
+	timeout time.Duration
+	cOpts   []grpc.CallOption
+}
+
+// withDeadline limits a single agency call with the configured deadline, zero timeout means no deadline
+func withDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (f *Agency) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withDeadline(ctx, f.callTimeout)
+}
+
+// toAgencyError converts connection, deadline and cancellation errors to typed agency errors
+func toAgencyError(err error) error {
+	code := grpcStatus.Code(err)
+	switch {
+	case errors.Is(err, model.ErrUnavailable):
+		return err
+	case code == codes.Unavailable:
+		return fmt.Errorf("%w: %s", model.ErrUnavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded) || code == codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %s", model.ErrTimeout, err.Error())
+	case errors.Is(err, context.Canceled) || code == codes.Canceled:
+		return fmt.Errorf("%w: %s", model.ErrCancelled, err.Error())
+	}
+	return err
 }
 
 func (f *Agency) callOptions(jwtToken string) []grpc.CallOption {
@@ -62,16 +95,22 @@ func (f *Agency) userSyncClient(a *model.Agent, connectionID string) *async.Pair
 	return async.NewPairwise(f.conn, connectionID, opts...)
 }
 
+// Connection configuration for "sync" protocol management requests coming directly from web wallet
+func (f *Agency) userClient(ctx context.Context, a *model.Agent) *Client {
+	opts := f.callOptions(a.RawJWT)
+	return &Client{&f.conn, ctx, f.callTimeout, opts}
+}
+
 // Connection configuration for "async" requests, done on behalf of the web wallet
 func (f *Agency) getUserAsyncClient(a *model.Agent) clientConn {
 	opts := f.callOptions(jwt.BuildJWT(a.AgentID))
-	return &Client{&f.conn, f.ctx, opts}
+	return &Client{&f.conn, f.ctx, f.callTimeout, opts}
 }
 
 // Connection configuration for agency administrative client
 func (f *Agency) adminClient() *Client {
 	opts := f.callOptions(jwt.BuildJWT(f.agencyAdminID))
-	return &Client{&f.conn, f.ctx, opts}
+	return &Client{&f.conn, f.ctx, f.callTimeout, opts}
 }
 
 func (c *Client) release(id string, protocolType agency.Protocol_Type) (pid *agency.ProtocolID, err error) {
@@ -79,7 +118,9 @@ func (c *Client) release(id string, protocolType agency.Protocol_Type) (pid *age
 		ID:     id,
 		TypeID: protocolType,
 	}
-	return c.Conn.DoRelease(c.ctx, protocolID, c.cOpts...)
+	ctx, cancel := withDeadline(c.ctx, c.timeout)
+	defer cancel()
+	return c.Conn.DoRelease(ctx, protocolID, c.cOpts...)
 }
 
 func (c *Client) status(id string, protocolType agency.Protocol_Type) (pid *agency.ProtocolStatus, err error) {
@@ -87,7 +128,9 @@ func (c *Client) status(id string, protocolType agency.Protocol_Type) (pid *agen
 		ID:     id,
 		TypeID: protocolType,
 	}
-	return c.Conn.DoStatus(c.ctx, protocolID, c.cOpts...)
+	ctx, cancel := withDeadline(c.ctx, c.timeout)
+	defer cancel()
+	return c.Conn.DoStatus(ctx, protocolID, c.cOpts...)
 }
 
 type AgentStatus struct {
@@ -95,14 +138,14 @@ type AgentStatus struct {
 	err    error
 }
 
-func (c *Client) listen(id string) (ch chan *AgentStatus, err error) {
+func (c *Client) listen(ctx context.Context, id string) (ch chan *AgentStatus, err error) {
 	clientID := &agency.ClientID{ID: id}
 	defer err2.Handle(&err)
 
 	agentClient := agency.NewAgentServiceClient(c.ClientConn)
 	statusCh := make(chan *AgentStatus)
 
-	stream := try.To1(agentClient.Listen(c.ctx, clientID, c.cOpts...))
+	stream := try.To1(agentClient.Listen(ctx, clientID, c.cOpts...))
 	utils.LogLow().Infoln("successful start of listen id:", clientID.ID)
 
 	go func() {
@@ -124,6 +167,6 @@ func (c *Client) listen(id string) (ch chan *AgentStatus, err error) {
 	return statusCh, nil
 }
 
-func (c *Client) psmHook() (ch chan *ops.AgencyStatus, err error) {
-	return c.Conn.PSMHook(c.ctx, c.cOpts...)
+func (c *Client) psmHook(ctx context.Context) (ch chan *ops.AgencyStatus, err error) {
+	return c.Conn.PSMHook(ctx, c.cOpts...)
 }
@@ -1,6 +1,9 @@
 package findy
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/findy-network/findy-agent-vault/agency/model"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
 	agency "github.com/findy-network/findy-common-go/grpc/agency/v1"
@@ -61,6 +64,7 @@ func statusToProof(status *agency.ProtocolStatus) *model.Proof {
 
 			if role == graph.ProofRoleVerifier {
 				values = append(values, &model.ProofValue{
+					ID:        v.ID,
 					Name:      v.Name,
 					CredDefID: v.CredDefID,
 					Value:     v.Value,
@@ -68,8 +72,13 @@ func statusToProof(status *agency.ProtocolStatus) *model.Proof {
 			}
 		}
 		return &model.Proof{
-			Role:          role,
-			Attributes:    attributes,
+			Role: role,
+			// agency status does not report the attribute restrictions other than cred def id,
+			// so provability of a received request is resolved without them
+			Attributes: attributes,
+			// agency status does not report the requested predicates,
+			// predicates of the requests sent by us are stored when sending
+			Predicates:    make([]*graph.ProofPredicate, 0),
 			Values:        values,
 			InitiatedByUs: false,
 		}
@@ -88,3 +97,134 @@ func statusToMessage(status *agency.ProtocolStatus) *model.Message {
 	}
 	return nil
 }
+
+func statusToPing(status *agency.ProtocolStatus) *model.Ping {
+	ping := status.GetTrustPing()
+	if ping != nil {
+		initiatedByUs := status.State.GetProtocolID().Role == agency.Protocol_INITIATOR
+		return &model.Ping{
+			// when other end pings us, we know they are reachable
+			Replied:       ping.Replied || !initiatedByUs,
+			InitiatedByUs: initiatedByUs,
+		}
+	}
+	return nil
+}
+
+// statusToFailure maps the final state of a failed protocol to the job failure, declined protocols end in NACK
+func statusToFailure(status *agency.ProtocolStatus) *model.JobFailure {
+	state := status.GetState()
+	if state == nil {
+		return nil
+	}
+	code := model.FailureCodeProtocolError
+	if state.State == agency.ProtocolState_NACK {
+		code = model.FailureCodeDeclined
+	}
+	return &model.JobFailure{
+		Code:    code,
+		Message: state.Info,
+	}
+}
+
+func toAgencyProtocolType(protocolType graph.ProtocolType) agency.Protocol_Type {
+	switch protocolType {
+	case graph.ProtocolTypeConnection:
+		return agency.Protocol_DIDEXCHANGE
+	case graph.ProtocolTypeCredential:
+		return agency.Protocol_ISSUE_CREDENTIAL
+	case graph.ProtocolTypeProof:
+		return agency.Protocol_PRESENT_PROOF
+	case graph.ProtocolTypeBasicMessage:
+		return agency.Protocol_BASIC_MESSAGE
+	case graph.ProtocolTypeTrustPing:
+		return agency.Protocol_TRUST_PING
+	case graph.ProtocolTypeNone:
+	}
+	return agency.Protocol_NONE
+}
+
+func toProtocolType(protocolType agency.Protocol_Type) graph.ProtocolType {
+	switch protocolType {
+	case agency.Protocol_DIDEXCHANGE:
+		return graph.ProtocolTypeConnection
+	case agency.Protocol_ISSUE_CREDENTIAL:
+		return graph.ProtocolTypeCredential
+	case agency.Protocol_PRESENT_PROOF:
+		return graph.ProtocolTypeProof
+	case agency.Protocol_BASIC_MESSAGE:
+		return graph.ProtocolTypeBasicMessage
+	case agency.Protocol_TRUST_PING:
+		return graph.ProtocolTypeTrustPing
+	case agency.Protocol_NONE:
+	}
+	return graph.ProtocolTypeNone
+}
+
+// indyRestriction is a restriction of the requested attribute or predicate in indy proof request format
+type indyRestriction struct {
+	SchemaID        string `json:"schema_id,omitempty"`
+	SchemaIssuerDID string `json:"schema_issuer_did,omitempty"`
+	SchemaName      string `json:"schema_name,omitempty"`
+	IssuerDID       string `json:"issuer_did,omitempty"`
+	CredDefID       string `json:"cred_def_id,omitempty"`
+}
+
+type indyAttribute struct {
+	Name         string            `json:"name"`
+	Restrictions []indyRestriction `json:"restrictions,omitempty"`
+}
+
+type indyPredicate struct {
+	Name         string            `json:"name"`
+	PType        string            `json:"p_type"`
+	PValue       int64             `json:"p_value"`
+	Restrictions []indyRestriction `json:"restrictions,omitempty"`
+}
+
+// toAttributesJSON converts attributes to indy requested attributes keyed by referent.
+// Indy requires that at least one of the restrictions matches, cred def id of the attribute
+// is required in addition to the restrictions, so it is included in each restriction.
+func toAttributesJSON(attributes []model.Attribute) (string, error) {
+	requested := make(map[string]indyAttribute, len(attributes))
+	for i, attr := range attributes {
+		a := indyAttribute{Name: attr.Name}
+		for _, r := range attr.Restrictions {
+			restriction := indyRestriction{
+				SchemaID:        r.SchemaID,
+				SchemaIssuerDID: r.SchemaIssuerDid,
+				SchemaName:      r.SchemaName,
+				IssuerDID:       r.IssuerDid,
+				CredDefID:       r.CredDefID,
+			}
+			if attr.CredDefID != "" {
+				restriction.CredDefID = attr.CredDefID
+			}
+			a.Restrictions = append(a.Restrictions, restriction)
+		}
+		if attr.CredDefID != "" && len(a.Restrictions) == 0 {
+			a.Restrictions = []indyRestriction{{CredDefID: attr.CredDefID}}
+		}
+		requested[model.AttributeReferent(i)] = a
+	}
+	res, err := json.Marshal(requested)
+	return string(res), err
+}
+
+// toPredicatesJSON converts predicates to indy requested predicates keyed by referent
+func toPredicatesJSON(predicates []model.Predicate) (string, error) {
+	requested := make(map[string]indyPredicate, len(predicates))
+	for i, pred := range predicates {
+		p := indyPredicate{
+			Name:   pred.Name,
+			PType:  pred.Operator,
+			PValue: pred.Value,
+		}
+		if pred.CredDefID != "" {
+			p.Restrictions = []indyRestriction{{CredDefID: pred.CredDefID}}
+		}
+		requested["predicate_"+strconv.Itoa(i)] = p
+	}
+	res, err := json.Marshal(requested)
+	return string(res), err
+}
@@ -0,0 +1,64 @@
+package findy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestToPredicatesJSON(t *testing.T) {
+	res, err := toPredicatesJSON([]model.Predicate{
+		{Name: "age", Operator: ">=", Value: 18, CredDefID: "cred-def-id"},
+		{Name: "score", Operator: ">", Value: 5},
+	})
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	var got map[string]indyPredicate
+	if err := json.Unmarshal([]byte(res), &got); err != nil {
+		t.Fatalf("Received invalid JSON %s: %s", res, err)
+	}
+	exp := map[string]indyPredicate{
+		"predicate_0": {Name: "age", PType: ">=", PValue: 18, Restrictions: []indyRestriction{{CredDefID: "cred-def-id"}}},
+		"predicate_1": {Name: "score", PType: ">", PValue: 5},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("Mismatch in predicates, expected: %+v got: %+v", exp, got)
+	}
+}
+
+func TestToAttributesJSON(t *testing.T) {
+	res, err := toAttributesJSON([]model.Attribute{
+		{Name: "name"},
+		{Name: "email", CredDefID: "cred-def-id"},
+		{Name: "address", CredDefID: "cred-def-id", Restrictions: []*graph.ProofRestriction{
+			{IssuerDid: "issuer-did"},
+			{SchemaName: "schema-name"},
+		}},
+		{Name: "phone", Restrictions: []*graph.ProofRestriction{{SchemaID: "schema-id", SchemaIssuerDid: "schema-issuer-did"}}},
+	})
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	var got map[string]indyAttribute
+	if err := json.Unmarshal([]byte(res), &got); err != nil {
+		t.Fatalf("Received invalid JSON %s: %s", res, err)
+	}
+	exp := map[string]indyAttribute{
+		"attribute_0": {Name: "name"},
+		"attribute_1": {Name: "email", Restrictions: []indyRestriction{{CredDefID: "cred-def-id"}}},
+		"attribute_2": {Name: "address", Restrictions: []indyRestriction{
+			{IssuerDID: "issuer-did", CredDefID: "cred-def-id"},
+			{SchemaName: "schema-name", CredDefID: "cred-def-id"},
+		}},
+		"attribute_3": {Name: "phone", Restrictions: []indyRestriction{{SchemaID: "schema-id", SchemaIssuerDID: "schema-issuer-did"}}},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("Mismatch in attributes, expected: %+v got: %+v", exp, got)
+	}
+}
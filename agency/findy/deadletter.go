@@ -0,0 +1,62 @@
+package findy
+
+import (
+	"context"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	agency "github.com/findy-network/findy-common-go/grpc/agency/v1"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// addDeadLetter stores the notification that could not be processed
+// so that it can be retried later instead of being lost
+func (f *Agency) addDeadLetter(
+	job *model.JobInfo,
+	notification *agency.Notification,
+	status *agency.ProtocolStatus,
+	cause error,
+) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Errorf("Unable to store dead letter for %s: %s", job.JobID, err.Error())
+	}))
+
+	try.To(f.vault.AddDeadLetter(&dbModel.DeadLetter{
+		Base:           dbModel.Base{TenantID: job.TenantID},
+		ProtocolID:     job.JobID,
+		ProtocolType:   toProtocolType(notification.ProtocolType),
+		ConnectionID:   job.ConnectionID,
+		Notification:   try.To1(protojson.Marshal(notification)),
+		ProtocolStatus: try.To1(protojson.Marshal(status)),
+		LastError:      cause.Error(),
+	}))
+
+	utils.LogMed().Infof("Stored dead letter for job %s, tenant %s", job.JobID, job.TenantID)
+}
+
+func (f *Agency) ProcessDeadLetter(ctx context.Context, a *model.Agent, letter *dbModel.DeadLetter) (err error) {
+	defer err2.Handle(&err, toAgencyError)
+
+	utils.LogMed().Infof("Process dead letter %s for job %s", letter.ID, letter.ProtocolID)
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	notification := &agency.Notification{}
+	try.To(unmarshal.Unmarshal(letter.Notification, notification))
+	status := &agency.ProtocolStatus{}
+	try.To(unmarshal.Unmarshal(letter.ProtocolStatus, status))
+	if status.State == nil {
+		// status was not available when the notification was received
+		status = try.To1(f.getStatus(a, notification))
+	}
+
+	job := &model.JobInfo{
+		TenantID:     letter.TenantID,
+		JobID:        letter.ProtocolID,
+		ConnectionID: letter.ConnectionID,
+	}
+	return f.handleNotification(ctx, a, job, notification, status)
+}
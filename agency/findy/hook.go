@@ -1,6 +1,7 @@
 package findy
 
 import (
+	"context"
 	"time"
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
@@ -36,29 +37,53 @@ func (f *Agency) archive(info *model.ArchiveInfo, status *agency.ProtocolStatus)
 	}
 }
 
-func (f *Agency) startHookOrWait() {
-	for {
-		err := f.listenAdminHook()
+// ListenHook listens to the PSM hook until the context is done or the agency is closed
+func (f *Agency) ListenHook(ctx context.Context) (err error) {
+	if f.isClosed() {
+		return model.ErrClosed
+	}
+
+	hookCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(f.streams, cancel)
+	context.AfterFunc(hookCtx, func() { stop() })
+
+	if err = f.listenAdminHook(hookCtx); err != nil {
+		cancel()
+	}
+	return err
+}
+
+func (f *Agency) startHookOrWait(ctx context.Context) {
+	for attempt := 0; ctx.Err() == nil; attempt++ {
+		err := f.listenAdminHook(ctx)
 		if err == nil {
 			break
 		}
-		glog.Warningf("listenAdminHook: cannot connect server, reconnecting after %d secs...", waitTime)
-		time.Sleep(waitTime * time.Second)
+		delay := reconnectDelay(attempt)
+		glog.Warningf("listenAdminHook: cannot connect server, reconnecting after %v...", delay)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
 	}
 }
 
-func (f *Agency) adminStatusLoop(ch chan *ops.AgencyStatus) {
+func (f *Agency) adminStatusLoop(ctx context.Context, ch chan *ops.AgencyStatus) {
 	defer err2.Catch(err2.Err(func(err error) {
 		glog.Errorf("Recovered error in psm hook routine: %s", err.Error())
-		go f.adminStatusLoop(ch)
+		go f.adminStatusLoop(ctx, ch)
 	}))
 
 	for {
 		status, ok := <-ch
 		if !ok {
+			if ctx.Err() != nil {
+				utils.LogMed().Infoln("Stop listening to PSM events")
+				break
+			}
 			glog.Warningln("listenAdminHook: server lost, try reconnecting...")
 			time.Sleep(waitTime * time.Second)
-			f.startHookOrWait()
+			f.startHookOrWait(ctx)
 			break
 		}
 		utils.LogMed().Infoln("received psm hook data for:", status.GetDID())
@@ -77,7 +102,11 @@ func (f *Agency) adminStatusLoop(ch chan *ops.AgencyStatus) {
 
 		// archive currently only successful protocol results
 		if protocolStatus.State.State == agency.ProtocolState_OK {
+			if !f.startHandling() {
+				continue
+			}
 			f.archive(info, protocolStatus)
+			f.handling.Done()
 		} else {
 			utils.LogLow().Infof(
 				"Skipping archiving for protocol run %s in state %s",
@@ -88,7 +117,7 @@ func (f *Agency) adminStatusLoop(ch chan *ops.AgencyStatus) {
 	}
 }
 
-func (f *Agency) listenAdminHook() (err error) {
+func (f *Agency) listenAdminHook(ctx context.Context) (err error) {
 	defer err2.Handle(&err)
 
 	glog.Info("Start listening to PSM events.")
@@ -96,8 +125,8 @@ func (f *Agency) listenAdminHook() (err error) {
 	cmd := f.adminClient()
 	// Error in registration is not notified here, instead all relevant info comes
 	// in stream callback from now on
-	ch := try.To1(cmd.psmHook())
+	ch := try.To1(cmd.psmHook(ctx))
 
-	go f.adminStatusLoop(ch)
+	go f.adminStatusLoop(ctx, ch)
 	return nil
 }
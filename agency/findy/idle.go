@@ -0,0 +1,84 @@
+package findy
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+const idleCheckInterval = time.Hour
+
+var (
+	suspendedListeners = expvar.NewInt("agent_listener_suspensions")
+	resumedListeners   = expvar.NewInt("agent_listener_resumptions")
+)
+
+// Listeners of the tenants that have not used the vault for a while are suspended
+// to limit the number of open agency streams. The listener is opened again
+// when the tenant accesses the vault. The job reconciliation recovers only the jobs
+// already stored in the vault: protocols started by others while the listener was
+// suspended are received only if the agency has queued their notifications
+// for the reconnected stream, otherwise they are missed. Idle timeout should be set long enough
+// that the suspended tenants are not expected to receive new protocols.
+
+// isIdle reports if the tenant has not accessed the vault within the timeout.
+// Tenants that have not accessed the vault at all are idle since their listener state was created.
+func isIdle(lastAccessed, created, now time.Time, timeout time.Duration) bool {
+	if lastAccessed.IsZero() {
+		lastAccessed = created
+	}
+	return timeout > 0 && now.Sub(lastAccessed) > timeout
+}
+
+// openListener opens the listener stream unless it is already open
+func (f *Agency) openListener(a *model.Agent) (err error) {
+	state := f.listenerState(a.TenantID)
+	previous, ok := state.activate()
+	if !ok {
+		return nil
+	}
+	if previous == listenerSuspended {
+		utils.LogMed().Infof("Resume listener for tenant %s", a.TenantID)
+		resumedListeners.Add(1)
+	}
+
+	if err = f.listenAgent(a); err != nil {
+		state.deactivate(previous)
+	}
+	return err
+}
+
+func (f *Agency) suspendIdleListeners(now time.Time) (count int) {
+	f.listeners.Range(func(_, value any) bool {
+		state := value.(*listenerState)
+		if state.suspendIfIdle(now, f.idleTimeout) {
+			utils.LogMed().Infof("Suspend idle listener for tenant %s", state.tenantID)
+			suspendedListeners.Add(1)
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+func (f *Agency) watchIdle() {
+	if f.idleTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.streams.Done():
+			return
+		case now := <-ticker.C:
+			if count := f.suspendIdleListeners(now.UTC()); count > 0 {
+				glog.Infof("Suspended %d idle agent listeners", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package findy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+)
+
+func TestIdleListeners(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		listens = make(map[string]int)
+		now     = time.Now().UTC()
+	)
+	testFindy := &Agency{
+		vault:       &statusListener{},
+		streams:     context.Background(),
+		idleTimeout: 24 * time.Hour,
+		userAsyncClient: func(a *model.Agent) clientConn {
+			lock.Lock()
+			defer lock.Unlock()
+			listens[a.TenantID]++
+			return &mockClientConn{}
+		},
+	}
+	listenCount := func(tenantID string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return listens[tenantID]
+	}
+
+	active := &model.Agent{TenantID: "idle-test-active", LastAccessed: now.Add(-time.Hour)}
+	idle := &model.Agent{TenantID: "idle-test-idle", LastAccessed: now.Add(-48 * time.Hour)}
+	testFindy.startListeners([]*model.Agent{active, idle})
+
+	if listenCount(active.TenantID) != 1 || listenCount(idle.TenantID) != 0 {
+		t.Errorf("Idle listener should not be started on startup, got %v", listens)
+	}
+	if !testFindy.listenerState(idle.TenantID).suspended() {
+		t.Errorf("Idle listener should be suspended, got %s", testFindy.listenerState(idle.TenantID))
+	}
+
+	// access resumes the listener, active listener is not opened twice
+	resumed := resumedListeners.Value()
+	if err := testFindy.AddAgent(idle); err != nil {
+		t.Fatalf("Unexpected error when resuming listener %s", err)
+	}
+	if err := testFindy.AddAgent(active); err != nil {
+		t.Fatalf("Unexpected error when adding agent %s", err)
+	}
+	if listenCount(active.TenantID) != 1 || listenCount(idle.TenantID) != 1 {
+		t.Errorf("Mismatch in listener count after access, got %v", listens)
+	}
+	if resumedListeners.Value() != resumed+1 {
+		t.Errorf("Mismatch in resumed listener count, expected: %d got: %d", resumed+1, resumedListeners.Value())
+	}
+
+	if count := testFindy.suspendIdleListeners(now.Add(time.Hour)); count != 0 {
+		t.Errorf("Recently accessed listeners should not be suspended, suspended %d", count)
+	}
+	if count := testFindy.suspendIdleListeners(now.Add(48 * time.Hour)); count != 2 {
+		t.Errorf("Mismatch in suspended listener count, expected: %d got: %d", 2, count)
+	}
+
+	if err := testFindy.AddAgent(active); err != nil {
+		t.Fatalf("Unexpected error when resuming listener %s", err)
+	}
+	if listenCount(active.TenantID) != 2 {
+		t.Errorf("Suspended listener should be resumed on access, got %v", listens)
+	}
+}
+
+func TestIsIdle(t *testing.T) {
+	const timeout = 24 * time.Hour
+	var (
+		now     = time.Now().UTC()
+		created = now.Add(-2 * timeout)
+	)
+	tests := []struct {
+		name         string
+		lastAccessed time.Time
+		created      time.Time
+		timeout      time.Duration
+		exp          bool
+	}{
+		{"recently accessed", now.Add(-time.Hour), created, timeout, false},
+		{"accessed before timeout", now.Add(-2 * timeout), created, timeout, true},
+		{"never accessed since startup", time.Time{}, created, timeout, true},
+		{"never accessed after recent startup", time.Time{}, now.Add(-time.Hour), timeout, false},
+		{"no timeout", time.Time{}, created, 0, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isIdle(tc.lastAccessed, tc.created, now, tc.timeout); got != tc.exp {
+				t.Errorf("Mismatch in idle, expected: %v got: %v", tc.exp, got)
+			}
+		})
+	}
+}
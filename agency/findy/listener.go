@@ -0,0 +1,243 @@
+package findy
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+type listenerStatus string
+
+const (
+	listenerConnecting   listenerStatus = "CONNECTING"
+	listenerConnected    listenerStatus = "CONNECTED"
+	listenerReconnecting listenerStatus = "RECONNECTING"
+	listenerSuspended    listenerStatus = "SUSPENDED"
+	listenerReleased     listenerStatus = "RELEASED"
+	listenerClosed       listenerStatus = "CLOSED"
+)
+
+var (
+	agentListeners = expvar.NewMap("agent_listeners")
+	staleListeners = expvar.NewInt("agent_listener_stale_streams")
+)
+
+// listenerState tracks the agent listener stream of a single tenant.
+// The states are published as expvars for operators.
+type listenerState struct {
+	mu sync.Mutex
+
+	tenantID      string
+	created       time.Time
+	status        listenerStatus
+	since         time.Time
+	lastKeepalive time.Time
+	lastAccessed  time.Time
+	retries       int
+
+	// cancel closes the current stream
+	cancel context.CancelFunc
+}
+
+func (f *Agency) listenerState(tenantID string) *listenerState {
+	value, loaded := f.listeners.LoadOrStore(tenantID, &listenerState{tenantID: tenantID, created: time.Now().UTC()})
+	state := value.(*listenerState)
+	if !loaded {
+		agentListeners.Set(tenantID, state)
+	}
+	return state
+}
+
+// activate reserves the listener for opening a new stream.
+// It fails if the stream is already open or being opened.
+func (s *listenerState) activate() (previous listenerStatus, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous = s.status
+	switch s.status {
+	case "", listenerSuspended, listenerReleased:
+		s.status = listenerConnecting
+		s.since = time.Now().UTC()
+		return previous, true
+	case listenerConnecting, listenerConnected, listenerReconnecting, listenerClosed:
+	}
+	return previous, false
+}
+
+// deactivate reverts the reservation when opening the stream fails
+func (s *listenerState) deactivate(previous listenerStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status == listenerConnecting {
+		s.status = previous
+	}
+}
+
+// connected records the opened stream. It fails if the listener
+// was stopped meanwhile and the stream should be closed.
+func (s *listenerState) connected(cancel context.CancelFunc) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStopped() {
+		return false
+	}
+
+	now := time.Now().UTC()
+	s.status = listenerConnected
+	s.since = now
+	s.lastKeepalive = now
+	s.cancel = cancel
+	return true
+}
+
+func (s *listenerState) reconnecting(retries int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStopped() {
+		return
+	}
+	if s.status != listenerReconnecting {
+		s.since = time.Now().UTC()
+	}
+	s.status = listenerReconnecting
+	s.retries = retries
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// suspendIfIdle suspends the listener if the tenant has not been accessed within the timeout.
+// Suspended stream is opened again when the tenant accesses the vault.
+// Listeners that are being opened are left as is.
+func (s *listenerState) suspendIfIdle(now time.Time, timeout time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := s.status == "" || s.status == listenerConnected || s.status == listenerReconnecting
+	if active && isIdle(s.lastAccessed, s.created, now, timeout) {
+		s.stop(listenerSuspended)
+		return true
+	}
+	return false
+}
+
+// release closes the stream of a tenant that is assigned to another replica
+func (s *listenerState) release() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.status {
+	case listenerConnecting, listenerConnected, listenerReconnecting, listenerSuspended:
+		s.stop(listenerReleased)
+		return true
+	case "", listenerReleased, listenerClosed:
+	}
+	return false
+}
+
+func (s *listenerState) suspended() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status == listenerSuspended
+}
+
+// stopped reports if the listener was stopped on purpose and should not be reconnected
+func (s *listenerState) stopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.isStopped()
+}
+
+func (s *listenerState) isStopped() bool {
+	return s.status == listenerSuspended || s.status == listenerReleased || s.status == listenerClosed
+}
+
+func (s *listenerState) touch(accessed time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if accessed.After(s.lastAccessed) {
+		s.lastAccessed = accessed
+	}
+}
+
+func (s *listenerState) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stop(listenerClosed)
+}
+
+func (s *listenerState) stop(status listenerStatus) {
+	s.status = status
+	s.since = time.Now().UTC()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *listenerState) keepalive() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastKeepalive = time.Now().UTC()
+	s.retries = 0
+}
+
+// stale reports if there has been no keepalive within the timeout
+func (s *listenerState) stale(now time.Time, timeout time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status == listenerConnected && now.Sub(s.lastKeepalive) > timeout
+}
+
+func (s *listenerState) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	js, _ := json.Marshal(struct {
+		Status        listenerStatus `json:"status"`
+		Since         time.Time      `json:"since"`
+		LastKeepalive time.Time      `json:"lastKeepalive"`
+		LastAccessed  time.Time      `json:"lastAccessed"`
+		Retries       int            `json:"retries"`
+	}{s.status, s.since, s.lastKeepalive, s.lastAccessed, s.retries})
+	return string(js)
+}
+
+// watchKeepalive closes the stream when the agency stops sending keepalives.
+// Closing the stream makes the status loop reconnect the listener.
+func (f *Agency) watchKeepalive(ctx context.Context, state *listenerState, cancel context.CancelFunc) {
+	if f.keepaliveTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(f.keepaliveTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if state.stale(now.UTC(), f.keepaliveTimeout) {
+				glog.Warningf("No keepalive from agency for tenant %s within %v, reconnecting...", state.tenantID, f.keepaliveTimeout)
+				staleListeners.Add(1)
+				cancel()
+				return
+			}
+		}
+	}
+}
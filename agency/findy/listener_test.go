@@ -0,0 +1,57 @@
+package findy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchKeepalive(t *testing.T) {
+	testFindy := &Agency{keepaliveTimeout: 40 * time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state := testFindy.listenerState("watchdog-tenant")
+	state.connected(cancel)
+	if !strings.Contains(agentListeners.Get("watchdog-tenant").String(), string(listenerConnected)) {
+		t.Errorf("Listener state not published, got %s", agentListeners.Get("watchdog-tenant"))
+	}
+
+	stale := staleListeners.Value()
+	done := make(chan struct{})
+	go func() {
+		testFindy.watchKeepalive(ctx, state, cancel)
+		close(done)
+	}()
+
+	// keepalives keep the stream open
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		state.keepalive()
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("Stream closed although keepalives were received")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Watchdog did not close stale stream")
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Stale stream was not cancelled")
+	}
+	if staleListeners.Value() != stale+1 {
+		t.Errorf("Mismatch in stale stream count, expected: %d got: %d", stale+1, staleListeners.Value())
+	}
+
+	state.reconnecting(2)
+	if state.stale(time.Now().Add(time.Hour), testFindy.keepaliveTimeout) {
+		t.Errorf("Reconnecting listener should not be stale")
+	}
+	if !strings.Contains(state.String(), `"retries":2`) {
+		t.Errorf("Mismatch in listener state %s", state)
+	}
+}
@@ -0,0 +1,105 @@
+package findy
+
+import (
+	"expvar"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	agency "github.com/findy-network/findy-common-go/grpc/agency/v1"
+	"github.com/golang/glog"
+)
+
+var jobReconciliation = expvar.NewMap("job_reconciliation")
+
+// reconcileJobs fetches the current agency status for all open jobs of the agent
+// and processes the ones whose notifications were missed e.g. while the vault was down
+// or the listener was disconnected. Returns the protocol IDs of the repaired jobs with the type of the notification
+// that was handled for the job. The number of agents reconciled in parallel is limited
+// by the listener concurrency, as each open listener fetches the status of all its open jobs.
+func (f *Agency) reconcileJobs(a *model.Agent) (repaired map[string]agency.Notification_Type) {
+	if f.reconciling != nil {
+		f.reconciling <- struct{}{}
+		defer func() { <-f.reconciling }()
+	}
+
+	repaired = make(map[string]agency.Notification_Type)
+	if !f.startHandling() {
+		return repaired
+	}
+	defer f.handling.Done()
+
+	jobs, err := f.vault.OpenJobs(a.TenantID)
+	if err != nil {
+		glog.Errorf("Unable to fetch open jobs for tenant %s: %s", a.TenantID, err.Error())
+		jobReconciliation.Add("errors", 1)
+		return repaired
+	}
+
+	for _, job := range jobs {
+		if typeID, ok := f.reconcileJob(a, job); ok {
+			repaired[job.AgencyProtocolID()] = typeID
+		}
+	}
+
+	jobReconciliation.Add("checked", int64(len(jobs)))
+	jobReconciliation.Add("repaired", int64(len(repaired)))
+	utils.LogHigh().Infof("Reconciled %d/%d open jobs for tenant %s", len(repaired), len(jobs), a.TenantID)
+	return repaired
+}
+
+func (f *Agency) reconcileJob(a *model.Agent, job *dbModel.Job) (agency.Notification_Type, bool) {
+	protocolType := toAgencyProtocolType(job.ProtocolType)
+	if protocolType == agency.Protocol_NONE {
+		return 0, false
+	}
+
+	protocolID := job.AgencyProtocolID()
+	connectionID := protocolID
+	if job.ConnectionID != nil {
+		connectionID = *job.ConnectionID
+	}
+	info := &model.JobInfo{
+		TenantID:     a.TenantID,
+		JobID:        protocolID,
+		ConnectionID: connectionID,
+	}
+	notification := &agency.Notification{
+		TypeID:       agency.Notification_STATUS_UPDATE,
+		ProtocolID:   protocolID,
+		ProtocolType: protocolType,
+		ConnectionID: connectionID,
+	}
+
+	status, err := f.getStatus(a, notification)
+	if err != nil || status.State == nil {
+		return 0, false
+	}
+
+	switch status.State.State {
+	case agency.ProtocolState_OK, agency.ProtocolState_ERR, agency.ProtocolState_NACK:
+		utils.LogMed().Infof("Reconcile job %s: protocol in state %s", job.ID, status.State.State)
+		if err := f.handleStatus(a, info, notification, status); err != nil {
+			glog.Errorf("Unable to reconcile job %s: %s", job.ID, err.Error())
+			jobReconciliation.Add("errors", 1)
+			return 0, false
+		}
+		return notification.TypeID, true
+	case agency.ProtocolState_WAIT_ACTION:
+		// paused protocols initiated by others are already stored and wait for user action,
+		// the ones initiated by us are auto-accepted when the pause notification is received
+		if !job.InitiatedByUs {
+			return 0, false
+		}
+		utils.LogMed().Infof("Reconcile job %s: protocol waiting for action", job.ID)
+		notification.TypeID = agency.Notification_PROTOCOL_PAUSED
+		if err := f.handleAction(f.ctx, a, info, notification, status); err != nil {
+			glog.Errorf("Unable to reconcile job %s: %s", job.ID, err.Error())
+			jobReconciliation.Add("errors", 1)
+			return 0, false
+		}
+		return notification.TypeID, true
+	case agency.ProtocolState_RUNNING, agency.ProtocolState_ACK:
+	}
+	return 0, false
+}
@@ -0,0 +1,57 @@
+package findy
+
+import (
+	"expvar"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+var releasedListeners = expvar.NewInt("agent_listener_releases")
+
+// When the vault is scaled to multiple replicas, each tenant is listened only
+// by the replica that owns it. Other replicas serve the tenant requests but
+// leave the notification handling to the owner.
+
+// owner reports if this replica should listen to the tenant,
+// all tenants are owned until the ownership is set with Rebalance
+func (f *Agency) owner(tenantID string) bool {
+	f.ownerMu.RLock()
+	defer f.ownerMu.RUnlock()
+
+	return f.owns == nil || f.owns(tenantID)
+}
+
+// Rebalance sets the tenants owned by this replica. Listeners of the released
+// tenants are closed and the listeners of the owned agents are started in the background.
+func (f *Agency) Rebalance(owns func(tenantID string) bool, agents []*model.Agent) {
+	if f.isClosed() {
+		return
+	}
+
+	f.ownerMu.Lock()
+	f.owns = owns
+	f.ownerMu.Unlock()
+
+	released := 0
+	f.listeners.Range(func(_, value any) bool {
+		state := value.(*listenerState)
+		if !f.owner(state.tenantID) && state.release() {
+			utils.LogMed().Infof("Release listener for tenant %s", state.tenantID)
+			released++
+		}
+		return true
+	})
+	releasedListeners.Add(int64(released))
+
+	owned := make([]*model.Agent, 0)
+	for _, a := range agents {
+		if f.owner(a.TenantID) {
+			owned = append(owned, a)
+		}
+	}
+	glog.Infof("Rebalanced agent listeners: %d/%d tenants owned, %d released", len(owned), len(agents), released)
+
+	go f.startListeners(owned)
+}
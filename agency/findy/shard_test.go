@@ -0,0 +1,69 @@
+package findy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+)
+
+func TestRebalance(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		listens = make(map[string]int)
+	)
+	testFindy := &Agency{
+		vault:   &statusListener{},
+		streams: context.Background(),
+		userAsyncClient: func(a *model.Agent) clientConn {
+			lock.Lock()
+			defer lock.Unlock()
+			listens[a.TenantID]++
+			return &mockClientConn{}
+		},
+	}
+	listenCount := func(tenantID string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return listens[tenantID]
+	}
+
+	kept := &model.Agent{TenantID: "shard-test-kept", LastAccessed: time.Now()}
+	moved := &model.Agent{TenantID: "shard-test-moved", LastAccessed: time.Now()}
+	testFindy.startListeners([]*model.Agent{kept, moved})
+
+	released := releasedListeners.Value()
+	testFindy.Rebalance(func(tenantID string) bool {
+		return tenantID == kept.TenantID
+	}, []*model.Agent{kept, moved})
+
+	if state := testFindy.listenerState(moved.TenantID); !state.stopped() {
+		t.Errorf("Listener of moved tenant should be released, got %s", state)
+	}
+	if state := testFindy.listenerState(kept.TenantID); state.stopped() {
+		t.Errorf("Listener of kept tenant should stay open, got %s", state)
+	}
+	if releasedListeners.Value() != released+1 {
+		t.Errorf("Mismatch in released listener count, expected: %d got: %d", released+1, releasedListeners.Value())
+	}
+
+	// access through this replica does not open the listener of other replica
+	if err := testFindy.AddAgent(moved); err != nil {
+		t.Fatalf("Unexpected error when adding agent %s", err)
+	}
+	if listenCount(moved.TenantID) != 1 || listenCount(kept.TenantID) != 1 {
+		t.Errorf("Mismatch in listener count after rebalance, got %v", listens)
+	}
+
+	// tenant moves back
+	testFindy.Rebalance(func(string) bool { return true }, []*model.Agent{kept, moved})
+	deadline := time.Now().Add(time.Second)
+	for listenCount(moved.TenantID) != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if listenCount(moved.TenantID) != 2 || listenCount(kept.TenantID) != 1 {
+		t.Errorf("Released listener should be opened again, got %v", listens)
+	}
+}
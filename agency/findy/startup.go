@@ -0,0 +1,139 @@
+package findy
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+const startupProgressSteps = 10
+
+// listenerStartup counts the listeners of all startups, startup is run again on each rebalance
+var listenerStartup = expvar.NewMap("agent_listener_startup")
+
+// startupProgress reports how the listener startup proceeds
+type startupProgress struct {
+	sync.Mutex
+	total     int
+	done      int
+	failed    int
+	suspended int
+	step      int
+	started   time.Time
+}
+
+func newStartupProgress(total int) *startupProgress {
+	listenerStartup.Add("runs", 1)
+	listenerStartup.Add("total", int64(total))
+	listenerStartup.Add("started", 0)
+	listenerStartup.Add("failed", 0)
+	listenerStartup.Add("suspended", 0)
+
+	step := total / startupProgressSteps
+	if step == 0 {
+		step = 1
+	}
+	return &startupProgress{total: total, step: step, started: time.Now()}
+}
+
+func (p *startupProgress) add(err error, suspended bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.done++
+	switch {
+	case suspended:
+		p.suspended++
+		listenerStartup.Add("suspended", 1)
+	case err != nil:
+		p.failed++
+		listenerStartup.Add("failed", 1)
+	default:
+		listenerStartup.Add("started", 1)
+	}
+	if p.done%p.step == 0 || p.done == p.total {
+		utils.LogLow().Infof("Processed %d/%d agent listeners (%d failed, %d idle)", p.done, p.total, p.failed, p.suspended)
+	}
+}
+
+func (p *startupProgress) finish() {
+	p.Lock()
+	defer p.Unlock()
+
+	elapsed := time.Since(p.started)
+	lastDuration := new(expvar.Int)
+	lastDuration.Set(elapsed.Milliseconds())
+	listenerStartup.Set("lastDurationMs", lastDuration)
+	glog.Infof("Agent listener startup done in %v: %d/%d started, %d failed, %d idle",
+		elapsed, p.done-p.failed-p.suspended, p.total, p.failed, p.suspended)
+}
+
+// startListeners opens the agent listeners in the background, most recently accessed agents first.
+// The number of parallel stream openings and the startup rate are limited
+// so that the agency is not flooded when there are lots of tenants.
+func (f *Agency) startListeners(agents []*model.Agent) {
+	sorted := make([]*model.Agent, len(agents))
+	copy(sorted, agents)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LastAccessed.After(sorted[j].LastAccessed)
+	})
+
+	concurrency := f.listenerConcurrency
+	if concurrency <= 0 || concurrency > len(sorted) {
+		concurrency = len(sorted)
+	}
+	utils.LogMed().Infof("Start %d agent listeners, concurrency %d, rate %d/s",
+		len(sorted), concurrency, f.listenerStartRate)
+
+	var throttle <-chan time.Time
+	if f.listenerStartRate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(f.listenerStartRate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	progress := newStartupProgress(len(sorted))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	now := time.Now().UTC()
+	for index, a := range sorted {
+		if f.isClosed() {
+			glog.Warningf("Agency closed, skip starting %d agent listeners", len(sorted)-index)
+			break
+		}
+
+		state := f.listenerState(a.TenantID)
+		state.touch(a.LastAccessed)
+		if state.suspendIfIdle(now, f.idleTimeout) {
+			// listener is opened when the agent accesses the vault
+			progress.add(nil, true)
+			continue
+		}
+
+		if throttle != nil && index > 0 {
+			<-throttle
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(a *model.Agent) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			err := f.openListener(a)
+			if err != nil {
+				glog.Errorf("Unable to start listener for tenant %s: %s", a.TenantID, err.Error())
+			}
+			progress.add(err, false)
+		}(a)
+	}
+	wg.Wait()
+	progress.finish()
+}
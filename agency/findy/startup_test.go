@@ -0,0 +1,65 @@
+package findy
+
+import (
+	"context"
+	"expvar"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/model"
+)
+
+func TestStartListeners(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		started = make([]string, 0)
+		now     = time.Now()
+	)
+	testFindy := &Agency{
+		vault:               &statusListener{},
+		streams:             context.Background(),
+		listenerConcurrency: 1,
+		listenerStartRate:   100,
+		userAsyncClient: func(a *model.Agent) clientConn {
+			lock.Lock()
+			defer lock.Unlock()
+			started = append(started, a.TenantID)
+			return &mockClientConn{}
+		},
+	}
+
+	agents := []*model.Agent{
+		{TenantID: "old", LastAccessed: now.Add(-time.Hour)},
+		{TenantID: "newest", LastAccessed: now},
+		{TenantID: "never"},
+		{TenantID: "recent", LastAccessed: now.Add(-time.Minute)},
+	}
+	startedBefore, failedBefore := startupMetric("started"), startupMetric("failed")
+	begin := time.Now()
+	testFindy.startListeners(agents)
+
+	// three intervals between four starts
+	if elapsed := time.Since(begin); elapsed < 30*time.Millisecond {
+		t.Errorf("Listener startup was not rate limited, took %v", elapsed)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	expected := []string{"newest", "recent", "old", "never"}
+	if !reflect.DeepEqual(expected, started) {
+		t.Errorf("Mismatch in listener startup order, expected: %v got: %v", expected, started)
+	}
+	// metrics are not reset between the startups
+	if startupMetric("started")-startedBefore != 4 || startupMetric("failed") != failedBefore {
+		t.Errorf("Mismatch in startup metrics %s", listenerStartup)
+	}
+}
+
+func startupMetric(key string) int64 {
+	if value, ok := listenerStartup.Get(key).(*expvar.Int); ok {
+		return value.Value()
+	}
+	return 0
+}
@@ -1,6 +1,9 @@
 package findy
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
@@ -24,33 +27,31 @@ func (c *counter) reset() {
 	c.lastCode = codes.Unknown
 }
 
-func (f *Agency) getStatus(a *model.Agent, notification *agency.Notification) (status *agency.ProtocolStatus, ok bool) {
+func (f *Agency) getStatus(a *model.Agent, notification *agency.Notification) (status *agency.ProtocolStatus, err error) {
 	cmd := f.userAsyncClient(a)
 
-	status, err := cmd.status(notification.ProtocolID, notification.ProtocolType)
-
+	status, err = cmd.status(notification.ProtocolID, notification.ProtocolType)
 	if err != nil {
 		glog.Errorf("Unable to fetch protocol status for %s (%s)", notification.ProtocolID, err.Error())
-		return
+		return nil, err
 	}
 
 	if status == nil {
 		glog.Errorf("Received invalid protocol status for %s", notification.ProtocolID)
-		return
+		return nil, fmt.Errorf("received invalid protocol status for %s", notification.ProtocolID)
 	}
-
-	ok = true
-	return
+	return status, nil
 }
 
 func (f *Agency) handleProtocolFailure(
 	job *model.JobInfo,
 	notification *agency.Notification,
+	status *agency.ProtocolStatus,
 ) (err error) {
 	defer err2.Handle(&err)
 
-	// TODO: failure reason
-	utils.LogHigh().Infof("Job %s (%s) failed", job.JobID, notification.ProtocolType.String())
+	failure := statusToFailure(status)
+	utils.LogHigh().Infof("Job %s (%s) failed: %+v", job.JobID, notification.ProtocolType.String(), failure)
 
 	now := f.currentTimeMs()
 	switch notification.ProtocolType {
@@ -60,6 +61,7 @@ func (f *Agency) handleProtocolFailure(
 			nil,
 			&model.CredentialUpdate{
 				FailedMs: &now,
+				Failure:  failure,
 			},
 		))
 	case agency.Protocol_PRESENT_PROOF:
@@ -68,10 +70,29 @@ func (f *Agency) handleProtocolFailure(
 			nil,
 			&model.ProofUpdate{
 				FailedMs: &now,
+				Failure:  failure,
+			},
+		))
+	case agency.Protocol_TRUST_PING:
+		try.To(f.vault.UpdatePing(
+			job,
+			nil,
+			&model.PingUpdate{
+				FailedMs: &now,
+				Failure:  failure,
+			},
+		))
+	case agency.Protocol_BASIC_MESSAGE:
+		try.To(f.vault.UpdateMessage(
+			job,
+			nil,
+			&model.MessageUpdate{
+				Delivered: false,
+				Failure:   failure,
 			},
 		))
 	default:
-		try.To(f.vault.FailJob(job))
+		try.To(f.vault.FailJob(job, failure))
 	}
 	return err
 }
@@ -103,8 +124,12 @@ func (f *Agency) handleProtocolSuccess(
 			return err
 		}
 
-		// TODO: delivered?
-		try.To(f.vault.AddMessage(job, message))
+		if message.SentByMe {
+			// existing message gets updated
+			try.To(f.vault.UpdateMessage(job, message, &model.MessageUpdate{Delivered: true}))
+		} else {
+			try.To(f.vault.AddMessage(job, message))
+		}
 
 	case agency.Protocol_ISSUE_CREDENTIAL:
 		try.To(f.vault.UpdateCredential(
@@ -122,8 +147,22 @@ func (f *Agency) handleProtocolSuccess(
 				VerifiedMs: &now,
 			},
 		))
-	case agency.Protocol_NONE:
 	case agency.Protocol_TRUST_PING:
+		ping := statusToPing(status)
+		if ping == nil {
+			glog.Errorf("Received invalid trust ping object for %s", job.JobID)
+			return err
+		}
+
+		update := &model.PingUpdate{RepliedMs: &now}
+		if !ping.Replied {
+			update = &model.PingUpdate{
+				FailedMs: &now,
+				Failure:  &model.JobFailure{Code: model.FailureCodeNoReply, Message: "No reply received"},
+			}
+		}
+		try.To(f.vault.UpdatePing(job, ping, update))
+	case agency.Protocol_NONE:
 	}
 
 	return nil
@@ -134,14 +173,13 @@ func (f *Agency) handleStatus(
 	job *model.JobInfo,
 	notification *agency.Notification,
 	status *agency.ProtocolStatus,
-) {
-	defer err2.Catch(err2.Err(func(err error) {
-		glog.Errorf("Error when handling action: %v", err)
-	}))
+) (err error) {
+	defer err2.Handle(&err)
 
 	switch status.State.State {
-	case agency.ProtocolState_ERR:
-		try.To(f.handleProtocolFailure(job, notification))
+	case agency.ProtocolState_ERR, agency.ProtocolState_NACK:
+		// protocol declined by either party ends in NACK, other failures in ERR
+		try.To(f.handleProtocolFailure(job, notification, status))
 		f.releaseCompleted(a, status.State.ProtocolID.ID, status.State.ProtocolID.TypeID)
 	case agency.ProtocolState_OK:
 		try.To(f.handleProtocolSuccess(job, notification, status))
@@ -153,17 +191,17 @@ func (f *Agency) handleStatus(
 			status.State.GetState().String(),
 		)
 	}
+	return err
 }
 
 func (f *Agency) handleAction(
+	ctx context.Context,
 	a *model.Agent,
 	job *model.JobInfo,
 	notification *agency.Notification,
 	status *agency.ProtocolStatus,
-) {
-	defer err2.Catch(err2.Err(func(err error) {
-		glog.Errorf("Error when handling action: %v", err)
-	}))
+) (err error) {
+	defer err2.Handle(&err)
 
 	switch notification.ProtocolType {
 	case agency.Protocol_ISSUE_CREDENTIAL:
@@ -172,8 +210,15 @@ func (f *Agency) handleAction(
 			glog.Errorf("Received invalid credential issue object for %s", job.JobID)
 			return
 		}
-		// TODO: what if we are issuer?
-		try.To1(f.vault.AddCredential(job, credential))
+
+		if credential.Role == graph.CredentialRoleIssuer {
+			// always auto-accept when we are issuer, the offer was sent by us
+			// existing credential gets updated
+			try.To(f.resumeCredentialOffer(ctx, a, job, credential, true))
+		} else {
+			// we are holder, add credential as new object
+			try.To1(f.vault.AddCredential(job, credential))
+		}
 
 	case agency.Protocol_PRESENT_PROOF:
 		proof := statusToProof(status)
@@ -185,7 +230,7 @@ func (f *Agency) handleAction(
 		if proof.Role == graph.ProofRoleVerifier {
 			// always auto-accept proof requests when we are verifier
 			// existing proof gets updated
-			try.To(f.resumeProofRequest(a, job, proof, true))
+			try.To(f.resumeProofRequest(ctx, a, job, proof, true))
 		} else {
 			// we are prover, add proof as new object
 			try.To1(f.vault.AddProof(job, proof))
@@ -198,27 +243,46 @@ func (f *Agency) handleAction(
 		// N/A
 		glog.Errorf("Should not handle action for protocol %s", notification.ProtocolType)
 	}
+	return err
 }
 
 func (f *Agency) handleNotification(
+	ctx context.Context,
 	a *model.Agent,
 	job *model.JobInfo,
 	notification *agency.Notification,
 	status *agency.ProtocolStatus,
-) {
+) error {
 	switch notification.TypeID {
 	case agency.Notification_PROTOCOL_PAUSED:
-		f.handleAction(a, job, notification, status)
+		return f.handleAction(ctx, a, job, notification, status)
 	case agency.Notification_STATUS_UPDATE:
-		f.handleStatus(a, job, notification, status)
+		return f.handleStatus(a, job, notification, status)
 	case agency.Notification_NONE:
 	case agency.Notification_KEEPALIVE:
-		// TODO?
+		// handled by the status loop
+	}
+	return nil
+}
+
+const (
+	baseReconnectDelay = time.Second
+	maxReconnectDelay  = 2 * time.Minute
+)
+
+// reconnectDelay doubles the delay for each failed attempt.
+// Half of the delay is randomized so that the listeners do not reconnect all at once.
+func reconnectDelay(attempt int) time.Duration {
+	delay := baseReconnectDelay << attempt
+	if delay <= 0 || delay > maxReconnectDelay {
+		delay = maxReconnectDelay
 	}
+	half := delay / 2
+	//#nosec
+	return half + time.Duration(rand.Int63n(int64(half)+1))
 }
 
 func (f *Agency) waitAndRetryListening(a *model.Agent, err error, retryCounter counter) counter {
-	const waitTime = 5
 	count := retryCounter.count
 
 	utils.LogLow().Infoln("Listen and wait", count)
@@ -228,6 +292,17 @@ func (f *Agency) waitAndRetryListening(a *model.Agent, err error, retryCounter c
 		errCode = e.Code()
 	}
 
+	state := f.listenerState(a.TenantID)
+	if f.isClosed() {
+		utils.LogMed().Infof("Agency closed, stop listening for tenant %s", a.TenantID)
+		state.close()
+		return retryCounter
+	}
+	if state.stopped() {
+		utils.LogMed().Infof("Listener stopped for tenant %s", a.TenantID)
+		return retryCounter
+	}
+
 	glog.Warningln("listenAgent: channel closed, try reconnecting...", count)
 	if errCode == retryCounter.lastCode {
 		count++
@@ -235,9 +310,18 @@ func (f *Agency) waitAndRetryListening(a *model.Agent, err error, retryCounter c
 		count = 0
 	}
 	for {
-		newWaitTime := count * waitTime
-		glog.Warningf("listenAgent: waiting, reconnecting after %d secs...", newWaitTime)
-		time.Sleep(time.Duration(newWaitTime) * time.Second)
+		state.reconnecting(count)
+		delay := reconnectDelay(count)
+		glog.Warningf("listenAgent: waiting, reconnecting after %v...", delay)
+		time.Sleep(delay)
+
+		if f.isClosed() {
+			state.close()
+			break
+		}
+		if state.stopped() {
+			break
+		}
 
 		err := f.listenAgentWithRetry(a, counter{count, errCode})
 		if err == nil {
@@ -245,20 +329,36 @@ func (f *Agency) waitAndRetryListening(a *model.Agent, err error, retryCounter c
 			break
 		}
 		glog.Warningf("listenAgent: cannot connect server, try again...")
+		count++
 	}
 
 	return counter{count, errCode}
 }
 
-func (f *Agency) agentStatusLoop(a *model.Agent, ch chan *AgentStatus, retryCounter counter) {
+// catchUpAndListen reconciles the open jobs before handling the notifications of the stream,
+// so that the notifications the agency has queued for the new stream are not handled concurrently with the repairs
+func (f *Agency) catchUpAndListen(a *model.Agent, ch chan *AgentStatus, retryCounter counter) {
+	f.agentStatusLoop(a, ch, retryCounter, f.reconcileJobs(a))
+}
+
+// agentStatusLoop handles the notifications of the stream. Notifications of the jobs
+// that were just repaired by the reconciliation are skipped.
+func (f *Agency) agentStatusLoop(
+	a *model.Agent,
+	ch chan *AgentStatus,
+	retryCounter counter,
+	reconciled map[string]agency.Notification_Type,
+) {
 	defer err2.Catch(err2.Err(func(err error) {
 		glog.Errorf("Recovered error in agent listener routine: %s, continue listening...", err.Error())
 
-		go f.agentStatusLoop(a, ch, counter{})
+		go f.agentStatusLoop(a, ch, counter{}, reconciled)
 	}))
 
 	utils.LogLow().Infoln("Start agentStatusLoop for", a.AgentID)
 
+	state := f.listenerState(a.TenantID)
+
 	for {
 		chRes, ok := <-ch
 		var chErr error
@@ -282,6 +382,7 @@ func (f *Agency) agentStatusLoop(a *model.Agent, ch chan *AgentStatus, retryCoun
 
 		if status.Notification.TypeID == agency.Notification_KEEPALIVE {
 			utils.LogTrace().Infof("Keepalive for agent %s", a.TenantID)
+			state.keepalive()
 			continue
 		}
 
@@ -290,18 +391,58 @@ func (f *Agency) agentStatusLoop(a *model.Agent, ch chan *AgentStatus, retryCoun
 			status.Notification.Role,
 			status.Notification.ProtocolID)
 
-		job := &model.JobInfo{
-			TenantID:     a.TenantID,
-			JobID:        status.Notification.ProtocolID,
-			ConnectionID: status.Notification.ConnectionID,
+		if typeID, ok := reconciled[status.Notification.ProtocolID]; ok && typeID == status.Notification.TypeID {
+			utils.LogMed().Infof("Skip notification %s, job was reconciled", status.Notification.ProtocolID)
+			delete(reconciled, status.Notification.ProtocolID)
+			continue
 		}
 
-		protocolStatus, ok := f.getStatus(a, status.Notification)
-		if !ok {
+		if !f.startHandling() {
+			f.deferNotification(a, status.Notification)
 			continue
 		}
+		f.handleAgentNotification(a, status.Notification)
+	}
+}
+
+func notificationJob(a *model.Agent, notification *agency.Notification) *model.JobInfo {
+	return &model.JobInfo{
+		TenantID:     a.TenantID,
+		JobID:        notification.ProtocolID,
+		ConnectionID: notification.ConnectionID,
+	}
+}
+
+// deferNotification stores the notification received while the agency is closing as a dead letter,
+// as the agency does not deliver a notification again once it is sent on the stream. Notifications received after the shutdown has drained
+// the handling are lost, as the vault storage is being closed.
+func (f *Agency) deferNotification(a *model.Agent, notification *agency.Notification) {
+	f.drainMu.RLock()
+	defer f.drainMu.RUnlock()
+
+	if f.drained {
+		glog.Warningf("Agency closed, dropped notification %s for tenant %s", notification.ProtocolID, a.TenantID)
+		return
+	}
+	utils.LogMed().Infof("Agency closing, store notification %s for retry", notification.ProtocolID)
+	f.addDeadLetter(notificationJob(a, notification), notification, &agency.ProtocolStatus{}, model.ErrClosed)
+}
+
+func (f *Agency) handleAgentNotification(a *model.Agent, notification *agency.Notification) {
+	defer f.handling.Done()
+
+	job := notificationJob(a, notification)
+
+	// the notification is not delivered again, so it is stored for retrying if the status is not available
+	protocolStatus, err := f.getStatus(a, notification)
+	if err != nil {
+		f.addDeadLetter(job, notification, &agency.ProtocolStatus{}, err)
+		return
+	}
 
-		f.handleNotification(a, job, status.Notification, protocolStatus)
+	if err := f.handleNotification(f.ctx, a, job, notification, protocolStatus); err != nil {
+		glog.Errorf("Error when handling notification for %s: %v", job.JobID, err)
+		f.addDeadLetter(job, notification, protocolStatus, err)
 	}
 }
 
@@ -316,11 +457,29 @@ func (f *Agency) listenAgentWithRetry(a *model.Agent, retryCounter counter) (err
 
 	cmd := f.userAsyncClient(a)
 
+	// stream is closed by the watchdog when the agency stops sending keepalives
+	ctx, cancel := context.WithCancel(f.streams)
+	defer err2.Handle(&err, func(err error) error {
+		cancel()
+		return err
+	})
+
 	// Error in registration is not notified here, instead all relevant info comes
 	// in stream callback from now on
-	ch := try.To1(cmd.listen(a.TenantID))
+	ch := try.To1(cmd.listen(ctx, a.TenantID))
+
+	// listener may have been suspended while connecting, the status loop
+	// stops when it receives the cancelled stream
+	state := f.listenerState(a.TenantID)
+	if !state.connected(cancel) {
+		cancel()
+		go f.agentStatusLoop(a, ch, retryCounter, nil)
+		return nil
+	}
+	go f.watchKeepalive(ctx, state, cancel)
 
-	go f.agentStatusLoop(a, ch, retryCounter)
+	// catch up with the protocols that progressed while we were not listening
+	go f.catchUpAndListen(a, ch, retryCounter)
 
 	return err
 }
@@ -1,8 +1,11 @@
 package findy
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/agency/model"
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
@@ -103,6 +106,7 @@ var (
 			Name:      "attribute-name",
 			CredDefID: "cred-def-id",
 		}},
+		Predicates:    []*graph.ProofPredicate{},
 		Values:        []*model.ProofValue{},
 		InitiatedByUs: false,
 	}
@@ -130,27 +134,55 @@ var (
 			},
 		}
 	}
+
+	testPingStatus = func(jobID string, state agency.ProtocolState_State, replied bool) *agency.ProtocolStatus {
+		return &agency.ProtocolStatus{
+			State: &agency.ProtocolState{
+				State: state,
+				ProtocolID: &agency.ProtocolID{
+					Role:   agency.Protocol_INITIATOR,
+					ID:     jobID,
+					TypeID: agency.Protocol_TRUST_PING,
+				},
+			},
+			Status: &agency.ProtocolStatus_TrustPing{
+				TrustPing: &agency.ProtocolStatus_TrustPingStatus{
+					Replied: replied,
+				},
+			},
+		}
+	}
 )
 
 type mockStorage struct {
 	info        *model.JobInfo
 	connection  *model.Connection
 	message     *model.Message
+	msgUpdate   *model.MessageUpdate
 	credUpdate  *model.CredentialUpdate
 	proofUpdate *model.ProofUpdate
 	credential  *model.Credential
 	proof       *model.Proof
+	ping        *model.Ping
+	pingUpdate  *model.PingUpdate
 	failedJob   *model.JobInfo
+	failure     *model.JobFailure
 }
 
 type statusListener struct {
 	conn     *mockStorage
 	msg      *mockStorage
+	msgUpt   *mockStorage
 	cred     *mockStorage
 	credUpt  *mockStorage
 	proof    *mockStorage
 	proofUpt *mockStorage
+	pingUpt  *mockStorage
 	failed   *mockStorage
+	jobs     []*dbModel.Job
+
+	deadLetters []*dbModel.DeadLetter
+	updateErr   error
 }
 
 func (s *statusListener) AddConnection(job *model.JobInfo, connection *model.Connection) error {
@@ -163,8 +195,9 @@ func (s *statusListener) AddMessage(job *model.JobInfo, message *model.Message)
 	return nil
 }
 
-func (s *statusListener) UpdateMessage(_ *model.JobInfo, _ *model.MessageUpdate) error {
-	panic("Not implemented")
+func (s *statusListener) UpdateMessage(job *model.JobInfo, message *model.Message, update *model.MessageUpdate) error {
+	s.msgUpt = &mockStorage{info: job, message: message, msgUpdate: update}
+	return nil
 }
 
 func (s *statusListener) AddCredential(job *model.JobInfo, credential *model.Credential) (*dbModel.Job, error) {
@@ -173,6 +206,9 @@ func (s *statusListener) AddCredential(job *model.JobInfo, credential *model.Cre
 }
 
 func (s *statusListener) UpdateCredential(job *model.JobInfo, _ *model.Credential, update *model.CredentialUpdate) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
 	s.credUpt = &mockStorage{info: job, credUpdate: update}
 	return nil
 }
@@ -187,33 +223,61 @@ func (s *statusListener) UpdateProof(job *model.JobInfo, _ *model.Proof, update
 	return nil
 }
 
-func (s *statusListener) FailJob(job *model.JobInfo) error {
-	s.failed = &mockStorage{failedJob: job}
+func (s *statusListener) AddPing(_ *model.JobInfo, _ *model.Ping) (*dbModel.Job, error) {
+	panic("Not implemented")
+}
+
+func (s *statusListener) UpdatePing(job *model.JobInfo, ping *model.Ping, update *model.PingUpdate) error {
+	s.pingUpt = &mockStorage{info: job, ping: ping, pingUpdate: update}
+	return nil
+}
+
+func (s *statusListener) FailJob(job *model.JobInfo, failure *model.JobFailure) error {
+	s.failed = &mockStorage{failedJob: job, failure: failure}
+	return nil
+}
+
+func (s *statusListener) OpenJobs(_ string) ([]*dbModel.Job, error) {
+	return s.jobs, nil
+}
+
+func (s *statusListener) AddDeadLetter(letter *dbModel.DeadLetter) error {
+	s.deadLetters = append(s.deadLetters, letter)
 	return nil
 }
 
 func (s *statusListener) connectionStorage() *mockStorage       { return s.conn }
 func (s *statusListener) messageStorage() *mockStorage          { return s.msg }
+func (s *statusListener) messageUpdateStorage() *mockStorage    { return s.msgUpt }
 func (s *statusListener) credentialStorage() *mockStorage       { return s.cred }
 func (s *statusListener) credentialUpdateStorage() *mockStorage { return s.credUpt }
 func (s *statusListener) proofStorage() *mockStorage            { return s.proof }
 func (s *statusListener) proofUpdateStorage() *mockStorage      { return s.proofUpt }
+func (s *statusListener) pingUpdateStorage() *mockStorage       { return s.pingUpt }
 func (s *statusListener) failedStorage() *mockStorage           { return s.failed }
 
 type mockClientConn struct {
+	statuses  map[string]*agency.ProtocolStatus
+	statusErr error
 }
 
 func (m *mockClientConn) release(_ string, _ agency.Protocol_Type) (pid *agency.ProtocolID, err error) {
 	return &agency.ProtocolID{}, nil
 }
-func (m *mockClientConn) status(_ string, _ agency.Protocol_Type) (pid *agency.ProtocolStatus, err error) {
+func (m *mockClientConn) status(id string, _ agency.Protocol_Type) (pid *agency.ProtocolStatus, err error) {
+	if m.statusErr != nil {
+		return nil, m.statusErr
+	}
+	if status, ok := m.statuses[id]; ok {
+		return status, nil
+	}
 	return &agency.ProtocolStatus{}, nil
 }
-func (m *mockClientConn) listen(_ string) (ch chan *AgentStatus, err error) {
+func (m *mockClientConn) listen(_ context.Context, _ string) (ch chan *AgentStatus, err error) {
 	ch = make(chan *AgentStatus)
 	return ch, nil
 }
-func (m *mockClientConn) psmHook() (ch chan *ops.AgencyStatus, err error) {
+func (m *mockClientConn) psmHook(_ context.Context) (ch chan *ops.AgencyStatus, err error) {
 	ch = make(chan *ops.AgencyStatus)
 	return ch, nil
 }
@@ -229,16 +293,37 @@ func TestHandleNotification(t *testing.T) {
 	}
 
 	var createJob = func(id string) *model.JobInfo { return &model.JobInfo{JobID: id} }
+	var withInfo = func(status *agency.ProtocolStatus, info string) *agency.ProtocolStatus {
+		status.State.Info = info
+		return status
+	}
+	var withState = func(status *agency.ProtocolStatus, state agency.ProtocolState_State) *agency.ProtocolStatus {
+		status.State.State = state
+		return status
+	}
+	var asInitiator = func(status *agency.ProtocolStatus) *agency.ProtocolStatus {
+		status.State.ProtocolID.Role = agency.Protocol_INITIATOR
+		return status
+	}
+	const testFailureInfo = "failure info"
+	testFailure := &model.JobFailure{Code: model.FailureCodeProtocolError, Message: testFailureInfo}
+	testDeclined := &model.JobFailure{Code: model.FailureCodeDeclined, Message: testFailureInfo}
 	const (
 		connName        = "connection"
 		msgName         = "message"
+		sentMsgName     = "sent_message"
 		credName        = "credential"
 		credUpdateName  = "cred_update"
 		proofName       = "proof"
 		proofUpdateName = "proof_update"
+		pingName        = "ping"
+		noReplyPingName = "no_reply_ping"
 		failedName      = "failed"
+		failedMsgName   = "failed_message"
 		failedCredName  = "failed_cred"
 		failedProofName = "failed_proof"
+		failedPingName  = "failed_ping"
+		declinedName    = "declined_proof"
 	)
 	tests := []struct {
 		name         string
@@ -270,6 +355,21 @@ func TestHandleNotification(t *testing.T) {
 			&mockStorage{info: createJob(msgName), message: testMessage},
 			listener.messageStorage,
 		},
+		{
+			sentMsgName,
+			createJob(sentMsgName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_BASIC_MESSAGE,
+			},
+			asInitiator(testMessageStatus(sentMsgName, agency.ProtocolState_OK)),
+			&mockStorage{
+				info:      createJob(sentMsgName),
+				message:   &model.Message{Message: testMessage.Message, SentByMe: true},
+				msgUpdate: &model.MessageUpdate{Delivered: true},
+			},
+			listener.messageUpdateStorage,
+		},
 		{
 			credName,
 			createJob(credName),
@@ -318,17 +418,63 @@ func TestHandleNotification(t *testing.T) {
 			&mockStorage{info: createJob(proofUpdateName), proofUpdate: &model.ProofUpdate{VerifiedMs: &now}},
 			listener.proofUpdateStorage,
 		},
+		{
+			pingName,
+			createJob(pingName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_TRUST_PING,
+				Role:         agency.Protocol_INITIATOR,
+			},
+			testPingStatus(pingName, agency.ProtocolState_OK, true),
+			&mockStorage{
+				info:       createJob(pingName),
+				ping:       &model.Ping{Replied: true, InitiatedByUs: true},
+				pingUpdate: &model.PingUpdate{RepliedMs: &now},
+			},
+			listener.pingUpdateStorage,
+		},
+		{
+			noReplyPingName,
+			createJob(noReplyPingName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_TRUST_PING,
+				Role:         agency.Protocol_INITIATOR,
+			},
+			testPingStatus(noReplyPingName, agency.ProtocolState_OK, false),
+			&mockStorage{
+				info: createJob(noReplyPingName),
+				ping: &model.Ping{Replied: false, InitiatedByUs: true},
+				pingUpdate: &model.PingUpdate{
+					FailedMs: &now,
+					Failure:  &model.JobFailure{Code: model.FailureCodeNoReply, Message: "No reply received"},
+				},
+			},
+			listener.pingUpdateStorage,
+		},
 		{
 			failedName,
 			createJob(failedName),
 			&agency.Notification{
 				TypeID:       agency.Notification_STATUS_UPDATE,
-				ProtocolType: agency.Protocol_BASIC_MESSAGE,
+				ProtocolType: agency.Protocol_DIDEXCHANGE,
 			},
-			testMessageStatus(failedName, agency.ProtocolState_ERR),
-			&mockStorage{failedJob: createJob(failedName)},
+			withInfo(withState(testConnectionStatus(failedName), agency.ProtocolState_ERR), testFailureInfo),
+			&mockStorage{failedJob: createJob(failedName), failure: testFailure},
 			listener.failedStorage,
 		},
+		{
+			failedMsgName,
+			createJob(failedMsgName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_BASIC_MESSAGE,
+			},
+			withInfo(asInitiator(testMessageStatus(failedMsgName, agency.ProtocolState_ERR)), testFailureInfo),
+			&mockStorage{info: createJob(failedMsgName), msgUpdate: &model.MessageUpdate{Delivered: false, Failure: testFailure}},
+			listener.messageUpdateStorage,
+		},
 		{
 			failedCredName,
 			createJob(failedCredName),
@@ -336,8 +482,8 @@ func TestHandleNotification(t *testing.T) {
 				TypeID:       agency.Notification_STATUS_UPDATE,
 				ProtocolType: agency.Protocol_ISSUE_CREDENTIAL,
 			},
-			testCredentialStatus(failedCredName, agency.ProtocolState_ERR),
-			&mockStorage{info: createJob(failedCredName), credUpdate: &model.CredentialUpdate{FailedMs: &now}},
+			withInfo(testCredentialStatus(failedCredName, agency.ProtocolState_ERR), testFailureInfo),
+			&mockStorage{info: createJob(failedCredName), credUpdate: &model.CredentialUpdate{FailedMs: &now, Failure: testFailure}},
 			listener.credentialUpdateStorage,
 		},
 		{
@@ -347,8 +493,30 @@ func TestHandleNotification(t *testing.T) {
 				TypeID:       agency.Notification_STATUS_UPDATE,
 				ProtocolType: agency.Protocol_PRESENT_PROOF,
 			},
-			testProofStatus(failedProofName, agency.ProtocolState_ERR),
-			&mockStorage{info: createJob(failedProofName), proofUpdate: &model.ProofUpdate{FailedMs: &now}},
+			withInfo(testProofStatus(failedProofName, agency.ProtocolState_ERR), testFailureInfo),
+			&mockStorage{info: createJob(failedProofName), proofUpdate: &model.ProofUpdate{FailedMs: &now, Failure: testFailure}},
+			listener.proofUpdateStorage,
+		},
+		{
+			failedPingName,
+			createJob(failedPingName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_TRUST_PING,
+			},
+			withInfo(testPingStatus(failedPingName, agency.ProtocolState_ERR, false), testFailureInfo),
+			&mockStorage{info: createJob(failedPingName), pingUpdate: &model.PingUpdate{FailedMs: &now, Failure: testFailure}},
+			listener.pingUpdateStorage,
+		},
+		{
+			declinedName,
+			createJob(declinedName),
+			&agency.Notification{
+				TypeID:       agency.Notification_STATUS_UPDATE,
+				ProtocolType: agency.Protocol_PRESENT_PROOF,
+			},
+			withInfo(testProofStatus(declinedName, agency.ProtocolState_NACK), testFailureInfo),
+			&mockStorage{info: createJob(declinedName), proofUpdate: &model.ProofUpdate{FailedMs: &now, Failure: testDeclined}},
 			listener.proofUpdateStorage,
 		},
 	}
@@ -356,7 +524,9 @@ func TestHandleNotification(t *testing.T) {
 	for _, testCase := range tests {
 		tc := testCase
 		t.Run(tc.name, func(t *testing.T) {
-			testFindy.handleNotification(&model.Agent{}, tc.job, tc.notification, tc.status)
+			if err := testFindy.handleNotification(context.Background(), &model.Agent{}, tc.job, tc.notification, tc.status); err != nil {
+				t.Errorf("Error when handling notification %s: %v", tc.name, err)
+			}
 			if !reflect.DeepEqual(tc.exp, tc.got()) {
 				t.Errorf("Mismatch in status %s, expected: %+v  got: %+v", tc.name, tc.exp, tc.got())
 			}
@@ -365,14 +535,258 @@ func TestHandleNotification(t *testing.T) {
 }
 
 func TestGetStatus(t *testing.T) {
-	status, ok := findy.getStatus(
+	status, err := findy.getStatus(
 		&model.Agent{AgentID: "user"},
 		&agency.Notification{ProtocolID: "id", ProtocolType: agency.Protocol_ISSUE_CREDENTIAL},
 	)
-	if !ok {
-		t.Errorf("Failure when getting status")
+	if err != nil {
+		t.Errorf("Failure when getting status: %s", err)
 	}
 	if status == nil {
 		t.Errorf("Received nil status")
 	}
 }
+
+func TestReconcileJobs(t *testing.T) {
+	now := utils.CurrentTimeMs()
+
+	var (
+		connectionID = "connection-id"
+		testJob      = func(id string, protocol graph.ProtocolType, initiatedByUs bool) *dbModel.Job {
+			return &dbModel.Job{
+				Base:          dbModel.Base{ID: id, TenantID: "tenant-id"},
+				ProtocolType:  protocol,
+				ConnectionID:  &connectionID,
+				Status:        graph.JobStatusWaiting,
+				InitiatedByUs: initiatedByUs,
+			}
+		}
+		testInfo = func(id string) *model.JobInfo {
+			return &model.JobInfo{TenantID: "tenant-id", JobID: id, ConnectionID: connectionID}
+		}
+		protocolID = "delivered-protocol"
+		outboxJob  = testJob("delivered", graph.ProtocolTypeProof, true)
+	)
+	outboxJob.ProtocolID = &protocolID
+
+	listener := &statusListener{
+		jobs: []*dbModel.Job{
+			testJob("issued", graph.ProtocolTypeCredential, false),
+			testJob("failed", graph.ProtocolTypeProof, false),
+			testJob("running", graph.ProtocolTypeTrustPing, true),
+			testJob("waiting", graph.ProtocolTypeCredential, false),
+			testJob("unknown", graph.ProtocolTypeNone, true),
+			outboxJob,
+		},
+	}
+	testFindy := &Agency{
+		vault:         listener,
+		currentTimeMs: func() int64 { return now },
+		userAsyncClient: func(a *model.Agent) clientConn {
+			return &mockClientConn{statuses: map[string]*agency.ProtocolStatus{
+				"issued":  testCredentialStatus("issued", agency.ProtocolState_OK),
+				"failed":  testProofStatus("failed", agency.ProtocolState_ERR),
+				"running": testPingStatus("running", agency.ProtocolState_RUNNING, false),
+				"waiting": testCredentialStatus("waiting", agency.ProtocolState_WAIT_ACTION),
+				// outbox job is queried with the protocol it started
+				protocolID: testProofStatus(protocolID, agency.ProtocolState_ERR),
+			}}
+		},
+	}
+
+	repaired := testFindy.reconcileJobs(&model.Agent{TenantID: "tenant-id"})
+	expRepaired := map[string]agency.Notification_Type{
+		"issued":   agency.Notification_STATUS_UPDATE,
+		"failed":   agency.Notification_STATUS_UPDATE,
+		protocolID: agency.Notification_STATUS_UPDATE,
+	}
+	if !reflect.DeepEqual(expRepaired, repaired) {
+		t.Errorf("Mismatch in repaired jobs, expected: %v got: %v", expRepaired, repaired)
+	}
+
+	expCred := &mockStorage{info: testInfo("issued"), credUpdate: &model.CredentialUpdate{IssuedMs: &now}}
+	if !reflect.DeepEqual(expCred, listener.credentialUpdateStorage()) {
+		t.Errorf("Mismatch in credential update, expected: %+v got: %+v", expCred, listener.credentialUpdateStorage())
+	}
+	if listener.proofUpdateStorage() == nil || listener.proofUpdateStorage().proofUpdate.FailedMs == nil {
+		t.Errorf("Expected failed proof update, got: %+v", listener.proofUpdateStorage())
+	}
+	if listener.proofUpdateStorage().info.JobID != protocolID {
+		t.Errorf("Expected last proof update for outbox job protocol %s, got: %+v", protocolID, listener.proofUpdateStorage().info)
+	}
+	if listener.pingUpdateStorage() != nil {
+		t.Errorf("Expected no ping update for running protocol, got: %+v", listener.pingUpdateStorage())
+	}
+	if listener.credentialStorage() != nil {
+		t.Errorf("Expected no new credential for waiting protocol, got: %+v", listener.credentialStorage())
+	}
+}
+
+type blockingListener struct {
+	*statusListener
+	opened  chan string
+	release chan struct{}
+}
+
+func (s *blockingListener) OpenJobs(tenantID string) ([]*dbModel.Job, error) {
+	s.opened <- tenantID
+	<-s.release
+	return nil, nil
+}
+
+func TestReconcileJobsBounded(t *testing.T) {
+	listener := &blockingListener{
+		statusListener: &statusListener{},
+		opened:         make(chan string),
+		release:        make(chan struct{}),
+	}
+	testFindy := &Agency{
+		vault:       listener,
+		reconciling: make(chan struct{}, 1),
+	}
+
+	for _, tenantID := range []string{"first", "second"} {
+		go testFindy.reconcileJobs(&model.Agent{TenantID: tenantID})
+	}
+
+	// first reconciliation holds the only slot until its jobs are fetched
+	<-listener.opened
+	select {
+	case tenantID := <-listener.opened:
+		t.Errorf("Reconciliation for %s started while the slot was taken", tenantID)
+	case <-time.After(20 * time.Millisecond):
+	}
+	listener.release <- struct{}{}
+
+	select {
+	case <-listener.opened:
+	case <-time.After(time.Second):
+		t.Errorf("Reconciliation did not start after the slot was released")
+	}
+	close(listener.release)
+}
+
+func TestDeadLetter(t *testing.T) {
+	now := utils.CurrentTimeMs()
+
+	listener := &statusListener{updateErr: errors.New("db failure")}
+	testFindy := &Agency{
+		vault:           listener,
+		currentTimeMs:   func() int64 { return now },
+		userAsyncClient: func(a *model.Agent) clientConn { return &mockClientConn{} },
+	}
+
+	var (
+		job          = &model.JobInfo{TenantID: "tenant-id", JobID: "dead-letter", ConnectionID: "connection-id"}
+		notification = &agency.Notification{
+			TypeID:       agency.Notification_STATUS_UPDATE,
+			ProtocolID:   job.JobID,
+			ProtocolType: agency.Protocol_ISSUE_CREDENTIAL,
+		}
+		status = testCredentialStatus(job.JobID, agency.ProtocolState_OK)
+	)
+
+	err := testFindy.handleNotification(context.Background(), &model.Agent{}, job, notification, status)
+	if err == nil {
+		t.Fatalf("Expected error when handling notification")
+	}
+	testFindy.addDeadLetter(job, notification, status, err)
+
+	if len(listener.deadLetters) != 1 {
+		t.Fatalf("Mismatch in dead letter count, expected: %d got: %d", 1, len(listener.deadLetters))
+	}
+	letter := listener.deadLetters[0]
+	if letter.TenantID != job.TenantID || letter.ProtocolID != job.JobID ||
+		letter.ProtocolType != graph.ProtocolTypeCredential || letter.LastError != err.Error() {
+		t.Errorf("Invalid dead letter %+v", letter)
+	}
+
+	listener.updateErr = nil
+	if err := testFindy.ProcessDeadLetter(context.Background(), &model.Agent{}, letter); err != nil {
+		t.Errorf("Error when processing dead letter %v", err)
+	}
+
+	exp := &mockStorage{info: job, credUpdate: &model.CredentialUpdate{IssuedMs: &now}}
+	if !reflect.DeepEqual(exp, listener.credentialUpdateStorage()) {
+		t.Errorf("Mismatch in credential update, expected: %+v got: %+v", exp, listener.credentialUpdateStorage())
+	}
+}
+
+func TestDeadLetterStatusFailure(t *testing.T) {
+	now := utils.CurrentTimeMs()
+
+	var (
+		job          = &model.JobInfo{TenantID: "tenant-id", JobID: "status-failure", ConnectionID: "connection-id"}
+		notification = &agency.Notification{
+			TypeID:       agency.Notification_STATUS_UPDATE,
+			ProtocolID:   job.JobID,
+			ProtocolType: agency.Protocol_ISSUE_CREDENTIAL,
+			ConnectionID: job.ConnectionID,
+		}
+	)
+
+	listener := &statusListener{}
+	client := &mockClientConn{
+		statuses:  map[string]*agency.ProtocolStatus{job.JobID: testCredentialStatus(job.JobID, agency.ProtocolState_OK)},
+		statusErr: errors.New("agency unavailable"),
+	}
+	testFindy := &Agency{
+		vault:           listener,
+		currentTimeMs:   func() int64 { return now },
+		userAsyncClient: func(a *model.Agent) clientConn { return client },
+	}
+
+	if !testFindy.startHandling() {
+		t.Fatalf("Unable to start handling")
+	}
+	testFindy.handleAgentNotification(&model.Agent{TenantID: job.TenantID}, notification)
+
+	if len(listener.deadLetters) != 1 {
+		t.Fatalf("Mismatch in dead letter count, expected: %d got: %d", 1, len(listener.deadLetters))
+	}
+	letter := listener.deadLetters[0]
+	if letter.ProtocolID != job.JobID || letter.LastError != client.statusErr.Error() {
+		t.Errorf("Invalid dead letter %+v", letter)
+	}
+
+	// status is fetched when the dead letter is processed
+	client.statusErr = nil
+	if err := testFindy.ProcessDeadLetter(context.Background(), &model.Agent{}, letter); err != nil {
+		t.Errorf("Error when processing dead letter %v", err)
+	}
+
+	exp := &mockStorage{info: job, credUpdate: &model.CredentialUpdate{IssuedMs: &now}}
+	if !reflect.DeepEqual(exp, listener.credentialUpdateStorage()) {
+		t.Errorf("Mismatch in credential update, expected: %+v got: %+v", exp, listener.credentialUpdateStorage())
+	}
+}
+
+func TestDeferNotificationOnClose(t *testing.T) {
+	listener := &statusListener{}
+	testFindy := &Agency{vault: listener, closed: true}
+
+	var (
+		a            = &model.Agent{TenantID: "tenant-id"}
+		notification = &agency.Notification{
+			TypeID:       agency.Notification_STATUS_UPDATE,
+			ProtocolID:   "closing",
+			ProtocolType: agency.Protocol_ISSUE_CREDENTIAL,
+			ConnectionID: "connection-id",
+		}
+	)
+
+	// notification received while closing is not delivered again, it is stored for retry
+	testFindy.deferNotification(a, notification)
+	if len(listener.deadLetters) != 1 || listener.deadLetters[0].ProtocolID != notification.ProtocolID {
+		t.Fatalf("Expected dead letter for notification received while closing, got %+v", listener.deadLetters)
+	}
+	if listener.deadLetters[0].LastError != model.ErrClosed.Error() {
+		t.Errorf("Mismatch in dead letter error, expected: %s got: %s", model.ErrClosed, listener.deadLetters[0].LastError)
+	}
+
+	testFindy.drained = true
+	testFindy.deferNotification(a, notification)
+	if len(listener.deadLetters) != 1 {
+		t.Errorf("Expected no dead letter after drain, got %+v", listener.deadLetters)
+	}
+}
@@ -5,9 +5,12 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	model "github.com/findy-network/findy-agent-vault/agency/model"
+	model0 "github.com/findy-network/findy-agent-vault/db/model"
+	model1 "github.com/findy-network/findy-agent-vault/graph/model"
 	utils "github.com/findy-network/findy-agent-vault/utils"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,19 +52,61 @@ func (mr *MockAgencyMockRecorder) AddAgent(agent interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAgent", reflect.TypeOf((*MockAgency)(nil).AddAgent), agent)
 }
 
+// CancelJob mocks base method.
+func (m *MockAgency) CancelJob(ctx context.Context, a *model.Agent, job *model.JobInfo, protocol model1.ProtocolType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", ctx, a, job, protocol)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockAgencyMockRecorder) CancelJob(ctx, a, job, protocol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockAgency)(nil).CancelJob), ctx, a, job, protocol)
+}
+
+// Close mocks base method.
+func (m *MockAgency) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockAgencyMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockAgency)(nil).Close), ctx)
+}
+
 // Connect mocks base method.
-func (m *MockAgency) Connect(a *model.Agent, invitation string) (string, error) {
+func (m *MockAgency) Connect(ctx context.Context, a *model.Agent, invitation string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Connect", a, invitation)
+	ret := m.ctrl.Call(m, "Connect", ctx, a, invitation)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Connect indicates an expected call of Connect.
-func (mr *MockAgencyMockRecorder) Connect(a, invitation interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) Connect(ctx, a, invitation interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockAgency)(nil).Connect), a, invitation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockAgency)(nil).Connect), ctx, a, invitation)
+}
+
+// Health mocks base method.
+func (m *MockAgency) Health() *model.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(*model.Health)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockAgencyMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockAgency)(nil).Health))
 }
 
 // Init mocks base method.
@@ -77,74 +122,144 @@ func (mr *MockAgencyMockRecorder) Init(l, agents, archiver, config interface{})
 }
 
 // Invite mocks base method.
-func (m *MockAgency) Invite(a *model.Agent) (*model.InvitationData, error) {
+func (m *MockAgency) Invite(ctx context.Context, a *model.Agent) (*model.InvitationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Invite", a)
+	ret := m.ctrl.Call(m, "Invite", ctx, a)
 	ret0, _ := ret[0].(*model.InvitationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Invite indicates an expected call of Invite.
-func (mr *MockAgencyMockRecorder) Invite(a interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) Invite(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockAgency)(nil).Invite), ctx, a)
+}
+
+// ListenHook mocks base method.
+func (m *MockAgency) ListenHook(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenHook", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenHook indicates an expected call of ListenHook.
+func (mr *MockAgencyMockRecorder) ListenHook(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockAgency)(nil).Invite), a)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenHook", reflect.TypeOf((*MockAgency)(nil).ListenHook), ctx)
+}
+
+// PingConnection mocks base method.
+func (m *MockAgency) PingConnection(ctx context.Context, a *model.Agent, connectionID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingConnection", ctx, a, connectionID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PingConnection indicates an expected call of PingConnection.
+func (mr *MockAgencyMockRecorder) PingConnection(ctx, a, connectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingConnection", reflect.TypeOf((*MockAgency)(nil).PingConnection), ctx, a, connectionID)
+}
+
+// ProcessDeadLetter mocks base method.
+func (m *MockAgency) ProcessDeadLetter(ctx context.Context, a *model.Agent, letter *model0.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDeadLetter", ctx, a, letter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDeadLetter indicates an expected call of ProcessDeadLetter.
+func (mr *MockAgencyMockRecorder) ProcessDeadLetter(ctx, a, letter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDeadLetter", reflect.TypeOf((*MockAgency)(nil).ProcessDeadLetter), ctx, a, letter)
+}
+
+// Rebalance mocks base method.
+func (m *MockAgency) Rebalance(owns func(string) bool, agents []*model.Agent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Rebalance", owns, agents)
+}
+
+// Rebalance indicates an expected call of Rebalance.
+func (mr *MockAgencyMockRecorder) Rebalance(owns, agents interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebalance", reflect.TypeOf((*MockAgency)(nil).Rebalance), owns, agents)
 }
 
 // ResumeCredentialOffer mocks base method.
-func (m *MockAgency) ResumeCredentialOffer(a *model.Agent, job *model.JobInfo, accept bool) error {
+func (m *MockAgency) ResumeCredentialOffer(ctx context.Context, a *model.Agent, job *model.JobInfo, accept bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResumeCredentialOffer", a, job, accept)
+	ret := m.ctrl.Call(m, "ResumeCredentialOffer", ctx, a, job, accept)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResumeCredentialOffer indicates an expected call of ResumeCredentialOffer.
-func (mr *MockAgencyMockRecorder) ResumeCredentialOffer(a, job, accept interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) ResumeCredentialOffer(ctx, a, job, accept interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeCredentialOffer", reflect.TypeOf((*MockAgency)(nil).ResumeCredentialOffer), a, job, accept)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeCredentialOffer", reflect.TypeOf((*MockAgency)(nil).ResumeCredentialOffer), ctx, a, job, accept)
 }
 
 // ResumeProofRequest mocks base method.
-func (m *MockAgency) ResumeProofRequest(a *model.Agent, job *model.JobInfo, accept bool) error {
+func (m *MockAgency) ResumeProofRequest(ctx context.Context, a *model.Agent, job *model.JobInfo, accept bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResumeProofRequest", a, job, accept)
+	ret := m.ctrl.Call(m, "ResumeProofRequest", ctx, a, job, accept)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResumeProofRequest indicates an expected call of ResumeProofRequest.
-func (mr *MockAgencyMockRecorder) ResumeProofRequest(a, job, accept interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) ResumeProofRequest(ctx, a, job, accept interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProofRequest", reflect.TypeOf((*MockAgency)(nil).ResumeProofRequest), ctx, a, job, accept)
+}
+
+// SendCredentialOffer mocks base method.
+func (m *MockAgency) SendCredentialOffer(ctx context.Context, a *model.Agent, connectionID, credDefID string, attributes []*model1.CredentialValue) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCredentialOffer", ctx, a, connectionID, credDefID, attributes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCredentialOffer indicates an expected call of SendCredentialOffer.
+func (mr *MockAgencyMockRecorder) SendCredentialOffer(ctx, a, connectionID, credDefID, attributes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProofRequest", reflect.TypeOf((*MockAgency)(nil).ResumeProofRequest), a, job, accept)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCredentialOffer", reflect.TypeOf((*MockAgency)(nil).SendCredentialOffer), ctx, a, connectionID, credDefID, attributes)
 }
 
 // SendMessage mocks base method.
-func (m *MockAgency) SendMessage(a *model.Agent, connectionID, message string) (string, error) {
+func (m *MockAgency) SendMessage(ctx context.Context, a *model.Agent, connectionID, message string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendMessage", a, connectionID, message)
+	ret := m.ctrl.Call(m, "SendMessage", ctx, a, connectionID, message)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SendMessage indicates an expected call of SendMessage.
-func (mr *MockAgencyMockRecorder) SendMessage(a, connectionID, message interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) SendMessage(ctx, a, connectionID, message interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockAgency)(nil).SendMessage), a, connectionID, message)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockAgency)(nil).SendMessage), ctx, a, connectionID, message)
 }
 
 // SendProofRequest mocks base method.
-func (m *MockAgency) SendProofRequest(a *model.Agent, connectionID string, attributes []model.Attribute) (string, error) {
+func (m *MockAgency) SendProofRequest(ctx context.Context, a *model.Agent, connectionID string, attributes []model.Attribute, predicates []model.Predicate) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendProofRequest", a, connectionID, attributes)
+	ret := m.ctrl.Call(m, "SendProofRequest", ctx, a, connectionID, attributes, predicates)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SendProofRequest indicates an expected call of SendProofRequest.
-func (mr *MockAgencyMockRecorder) SendProofRequest(a, connectionID, attributes interface{}) *gomock.Call {
+func (mr *MockAgencyMockRecorder) SendProofRequest(ctx, a, connectionID, attributes, predicates interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendProofRequest", reflect.TypeOf((*MockAgency)(nil).SendProofRequest), a, connectionID, attributes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendProofRequest", reflect.TypeOf((*MockAgency)(nil).SendProofRequest), ctx, a, connectionID, attributes, predicates)
 }
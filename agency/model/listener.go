@@ -1,6 +1,8 @@
 package model
 
 import (
+	"strconv"
+
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/graph/model"
 )
@@ -16,6 +18,7 @@ type Message struct {
 
 type MessageUpdate struct {
 	Delivered bool
+	Failure   *JobFailure
 }
 
 type Credential struct {
@@ -25,32 +28,63 @@ type Credential struct {
 	InitiatedByUs       bool
 }
 
+type JobFailure struct {
+	Code, Message string
+}
+
+// Failure codes reported by the agency
+const (
+	FailureCodeProtocolError = "PROTOCOL_ERROR"
+	FailureCodeDeclined      = "DECLINED"
+	FailureCodeNoReply       = "NO_REPLY"
+)
+
 type CredentialUpdate struct {
 	ApprovedMs, IssuedMs, FailedMs *int64
+	Failure                        *JobFailure
 }
 
 type ProofValue struct {
+	// ID is the referent of the requested attribute, empty if not reported by the agency
+	ID        string
 	Name      string
 	CredDefID string
 	Value     string
 }
 
+// AttributeReferent is the referent of the attribute at given index in the proof requests we send
+func AttributeReferent(index int) string {
+	return "attribute_" + strconv.Itoa(index)
+}
+
 type Proof struct {
 	Role          model.ProofRole
 	Attributes    []*model.ProofAttribute
+	Predicates    []*model.ProofPredicate
 	Values        []*ProofValue
 	InitiatedByUs bool
 }
 
 type ProofUpdate struct {
 	ApprovedMs, VerifiedMs, FailedMs *int64
+	Failure                          *JobFailure
+}
+
+type Ping struct {
+	Replied       bool
+	InitiatedByUs bool
+}
+
+type PingUpdate struct {
+	RepliedMs, FailedMs *int64
+	Failure             *JobFailure
 }
 
 type Listener interface {
 	AddConnection(job *JobInfo, connection *Connection) error
 
 	AddMessage(job *JobInfo, message *Message) error
-	UpdateMessage(job *JobInfo, update *MessageUpdate) error
+	UpdateMessage(job *JobInfo, message *Message, update *MessageUpdate) error
 
 	AddCredential(job *JobInfo, credential *Credential) (*dbModel.Job, error)
 	UpdateCredential(job *JobInfo, credential *Credential, update *CredentialUpdate) error
@@ -58,7 +92,16 @@ type Listener interface {
 	AddProof(job *JobInfo, proof *Proof) (*dbModel.Job, error)
 	UpdateProof(job *JobInfo, proof *Proof, update *ProofUpdate) error
 
-	FailJob(job *JobInfo) error
+	AddPing(job *JobInfo, ping *Ping) (*dbModel.Job, error)
+	UpdatePing(job *JobInfo, ping *Ping, update *PingUpdate) error
+
+	FailJob(job *JobInfo, failure *JobFailure) error
+
+	// OpenJobs returns all jobs of the tenant that are not yet completed
+	OpenJobs(tenantID string) ([]*dbModel.Job, error)
+
+	// AddDeadLetter stores a notification that failed to process
+	AddDeadLetter(letter *dbModel.DeadLetter) error
 }
 
 type ArchiveInfo struct {
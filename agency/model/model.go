@@ -1,6 +1,12 @@
 package model
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/utils"
 )
 
@@ -15,6 +21,8 @@ type Agent struct {
 	RawJWT   string
 	TenantID string
 	AgentID  string
+	// LastAccessed is used to prioritize the listener startup
+	LastAccessed time.Time
 }
 
 type InvitationData struct {
@@ -23,19 +31,69 @@ type InvitationData struct {
 }
 
 type Attribute struct {
+	Name         string
+	CredDefID    string
+	Restrictions []*model.ProofRestriction
+}
+
+type Predicate struct {
 	Name      string
+	Operator  string
+	Value     int64
 	CredDefID string
 }
 
+// Agency call errors that are exposed to the client as typed errors
+var (
+	ErrTimeout     = errors.New("agency did not respond in time")
+	ErrCancelled   = errors.New("agency call was cancelled")
+	ErrUnavailable = errors.New("agency unavailable")
+	ErrClosed      = errors.New("agency connection is closed")
+)
+
+// Health describes the state of the agency connection
+type Health struct {
+	Breaker  model.BreakerState
+	Failures int
+	// RetryAt is set when the breaker is open
+	RetryAt time.Time
+}
+
 type Agency interface {
 	Init(l Listener, agents []*Agent, archiver Archiver, config *utils.Configuration)
 	AddAgent(agent *Agent) error
+	// Close stops listening to the agency and waits for the received notifications to be handled
+	Close(ctx context.Context) error
+	// ListenHook subscribes to the agency PSM hook for archiving until the context is done
+	ListenHook(ctx context.Context) error
+	// Rebalance limits the listeners to the tenants owned by this vault replica:
+	// listeners of the other tenants are closed and the owned agents are started
+	Rebalance(owns func(tenantID string) bool, agents []*Agent)
+
+	Invite(ctx context.Context, a *Agent) (*InvitationData, error)
+	Connect(ctx context.Context, a *Agent, invitation string) (string, error)
+	SendMessage(ctx context.Context, a *Agent, connectionID, message string) (string, error)
+	SendProofRequest(
+		ctx context.Context,
+		a *Agent,
+		connectionID string,
+		attributes []Attribute,
+		predicates []Predicate,
+	) (string, error)
+	SendCredentialOffer(
+		ctx context.Context,
+		a *Agent,
+		connectionID, credDefID string,
+		attributes []*model.CredentialValue,
+	) (string, error)
+	PingConnection(ctx context.Context, a *Agent, connectionID string) (string, error)
+
+	ResumeCredentialOffer(ctx context.Context, a *Agent, job *JobInfo, accept bool) error
+	ResumeProofRequest(ctx context.Context, a *Agent, job *JobInfo, accept bool) error
+
+	CancelJob(ctx context.Context, a *Agent, job *JobInfo, protocol model.ProtocolType) error
 
-	Invite(a *Agent) (*InvitationData, error)
-	Connect(a *Agent, invitation string) (string, error)
-	SendMessage(a *Agent, connectionID, message string) (string, error)
-	SendProofRequest(a *Agent, connectionID string, attributes []Attribute) (string, error)
+	ProcessDeadLetter(ctx context.Context, a *Agent, letter *dbModel.DeadLetter) error
 
-	ResumeCredentialOffer(a *Agent, job *JobInfo, accept bool) error
-	ResumeProofRequest(a *Agent, job *JobInfo, accept bool) error
+	Health() *Health
 }
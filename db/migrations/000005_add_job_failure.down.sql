@@ -0,0 +1,2 @@
+ALTER TABLE "job" DROP COLUMN failure_reason;
+ALTER TABLE "job" DROP COLUMN failure_code;
@@ -0,0 +1,2 @@
+ALTER TABLE "job" ADD COLUMN failure_code VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE "job" ADD COLUMN failure_reason VARCHAR(4096) NOT NULL DEFAULT '';
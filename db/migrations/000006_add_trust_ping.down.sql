@@ -0,0 +1,3 @@
+ALTER TABLE "connection" DROP COLUMN last_ping_result;
+ALTER TABLE "connection" DROP COLUMN last_ping;
+ALTER TABLE "connection" DROP COLUMN last_seen;
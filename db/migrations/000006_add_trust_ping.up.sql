@@ -0,0 +1,5 @@
+ALTER TYPE protocol_type ADD VALUE 'TRUST_PING';
+
+ALTER TABLE "connection" ADD COLUMN last_seen timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
+ALTER TABLE "connection" ADD COLUMN last_ping timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
+ALTER TABLE "connection" ADD COLUMN last_ping_result BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "proof_predicate_proof_index";
+
+DROP TABLE IF EXISTS "proof_predicate";
@@ -0,0 +1,13 @@
+CREATE TABLE "proof_predicate"(
+  id uuid PRIMARY KEY DEFAULT uuid_generate_v4 (),
+  proof_id uuid NOT NULL,
+  "name" VARCHAR(1024) NOT NULL,
+  "operator" VARCHAR(2) NOT NULL,
+  "value" BIGINT NOT NULL,
+  "cred_def_id" VARCHAR(4096) NOT NULL DEFAULT '',
+  index SMALLINT NOT NULL,
+  CONSTRAINT fk_proof_predicate_proof
+    FOREIGN KEY(proof_id) REFERENCES "proof"(id)
+);
+
+CREATE INDEX "proof_predicate_proof_index" ON proof_predicate (proof_id);
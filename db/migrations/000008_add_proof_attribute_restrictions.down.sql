@@ -0,0 +1 @@
+ALTER TABLE "proof_attribute" DROP COLUMN restrictions;
@@ -0,0 +1 @@
+ALTER TABLE "proof_attribute" ADD COLUMN restrictions JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "tenant_policy_cursor_index";
+
+DROP TABLE IF EXISTS "tenant_policy";
+
+DROP TYPE IF EXISTS "policy_action";
@@ -0,0 +1,19 @@
+CREATE TYPE "policy_action" AS ENUM ('ACCEPT', 'DECLINE');
+
+CREATE TABLE "tenant_policy"(
+  id uuid PRIMARY KEY DEFAULT uuid_generate_v4 (),
+  tenant_id uuid NOT NULL,
+  protocol_type protocol_type NOT NULL,
+  "action" policy_action NOT NULL,
+  connection_id VARCHAR(256) NOT NULL DEFAULT '',
+  their_label VARCHAR(1024) NOT NULL DEFAULT '',
+  their_did VARCHAR(1024) NOT NULL DEFAULT '',
+  cred_def_id VARCHAR(4096) NOT NULL DEFAULT '',
+  attributes VARCHAR(1024)[] NOT NULL DEFAULT '{}',
+  created timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  cursor BIGINT NOT NULL GENERATED ALWAYS AS (extract(epoch from created at time zone 'UTC') * 1000) STORED,
+  CONSTRAINT fk_tenant_policy_agent
+    FOREIGN KEY(tenant_id) REFERENCES agent(id)
+);
+
+CREATE INDEX "tenant_policy_cursor_index" ON tenant_policy (tenant_id, cursor);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "job_expires_index";
+ALTER TABLE "job" DROP COLUMN expires;
@@ -0,0 +1,3 @@
+ALTER TABLE "job" ADD COLUMN expires timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
+
+CREATE INDEX "job_expires_index" ON job (expires) WHERE status = 'WAITING';
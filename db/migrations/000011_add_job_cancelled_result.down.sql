@@ -0,0 +1 @@
+UPDATE job SET result = 'FAILURE' WHERE result = 'CANCELLED';
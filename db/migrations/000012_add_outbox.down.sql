@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "outbox_next_attempt_index";
+
+DROP TABLE IF EXISTS "outbox";
+
+ALTER TABLE "job" DROP COLUMN attempts;
@@ -0,0 +1,23 @@
+ALTER TABLE "job" ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE "outbox"(
+  id uuid PRIMARY KEY DEFAULT uuid_generate_v4 (),
+  tenant_id uuid NOT NULL,
+  connection_id uuid NOT NULL,
+  protocol_type protocol_type NOT NULL,
+  payload JSONB NOT NULL DEFAULT '{}',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  last_error VARCHAR(4096) NOT NULL DEFAULT '',
+  delivered timestamptz NOT NULL DEFAULT timestamp '0001-01-01',
+  failed timestamptz NOT NULL DEFAULT timestamp '0001-01-01',
+  created timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  cursor BIGINT NOT NULL GENERATED ALWAYS AS (extract(epoch from created at time zone 'UTC') * 1000) STORED,
+  CONSTRAINT fk_outbox_agent
+    FOREIGN KEY(tenant_id) REFERENCES agent(id),
+  CONSTRAINT fk_outbox_connection
+    FOREIGN KEY(connection_id, tenant_id) REFERENCES connection(id, tenant_id)
+);
+
+CREATE INDEX "outbox_next_attempt_index" ON outbox (next_attempt)
+  WHERE delivered = timestamp '0001-01-01' AND failed = timestamp '0001-01-01';
@@ -0,0 +1,9 @@
+CREATE TABLE "processed_notification"(
+  tenant_id uuid NOT NULL,
+  protocol_id VARCHAR(256) NOT NULL,
+  "state" VARCHAR(256) NOT NULL,
+  created timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  CONSTRAINT processed_notification_pkey PRIMARY KEY (tenant_id, protocol_id, "state"),
+  CONSTRAINT fk_processed_notification_agent
+    FOREIGN KEY(tenant_id) REFERENCES agent(id)
+);
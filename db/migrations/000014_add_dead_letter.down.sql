@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "dead_letter_next_attempt_index";
+
+DROP TABLE IF EXISTS "dead_letter";
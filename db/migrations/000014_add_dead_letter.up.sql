@@ -0,0 +1,18 @@
+CREATE TABLE "dead_letter"(
+  id uuid PRIMARY KEY DEFAULT uuid_generate_v4 (),
+  tenant_id uuid NOT NULL,
+  protocol_id VARCHAR(256) NOT NULL,
+  protocol_type protocol_type NOT NULL,
+  connection_id VARCHAR(256) NOT NULL DEFAULT '',
+  notification JSONB NOT NULL DEFAULT '{}',
+  protocol_status JSONB NOT NULL DEFAULT '{}',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  last_error VARCHAR(4096) NOT NULL DEFAULT '',
+  created timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  cursor BIGINT NOT NULL GENERATED ALWAYS AS (extract(epoch from created at time zone 'UTC') * 1000) STORED,
+  CONSTRAINT fk_dead_letter_agent
+    FOREIGN KEY(tenant_id) REFERENCES agent(id)
+);
+
+CREATE INDEX "dead_letter_next_attempt_index" ON dead_letter (next_attempt);
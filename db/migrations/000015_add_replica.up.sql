@@ -0,0 +1,5 @@
+CREATE TABLE "replica"(
+  id VARCHAR(256) PRIMARY KEY,
+  heartbeat timestamptz NOT NULL DEFAULT (now() at time zone 'UTC'),
+  created timestamptz NOT NULL DEFAULT (now() at time zone 'UTC')
+);
@@ -0,0 +1 @@
+ALTER TYPE job_result ADD VALUE 'EXPIRED';
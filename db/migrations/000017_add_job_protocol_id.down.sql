@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "job_protocol_id_index";
+
+ALTER TABLE "job" DROP COLUMN IF EXISTS protocol_id;
@@ -0,0 +1,3 @@
+ALTER TABLE "job" ADD COLUMN protocol_id uuid;
+
+CREATE UNIQUE INDEX "job_protocol_id_index" ON job (protocol_id, tenant_id) WHERE protocol_id IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "processed_notification_processed_index";
+
+DELETE FROM processed_notification WHERE processed = timestamp '0001-01-01';
+
+ALTER TABLE "processed_notification" DROP COLUMN IF EXISTS processed;
+ALTER TABLE "processed_notification" DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,6 @@
+ALTER TABLE "processed_notification" ADD COLUMN claimed_until timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
+ALTER TABLE "processed_notification" ADD COLUMN processed timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
+
+UPDATE processed_notification SET processed = created;
+
+CREATE INDEX "processed_notification_processed_index" ON processed_notification (processed);
@@ -0,0 +1 @@
+ALTER TABLE "dead_letter" DROP COLUMN IF EXISTS claimed_until;
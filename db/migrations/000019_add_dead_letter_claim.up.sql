@@ -0,0 +1 @@
+ALTER TABLE "dead_letter" ADD COLUMN claimed_until timestamptz NOT NULL DEFAULT timestamp '0001-01-01';
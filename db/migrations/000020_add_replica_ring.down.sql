@@ -0,0 +1 @@
+ALTER TABLE "replica" DROP COLUMN IF EXISTS ring;
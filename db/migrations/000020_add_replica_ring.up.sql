@@ -0,0 +1 @@
+ALTER TABLE "replica" ADD COLUMN ring TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE "event" DROP COLUMN cursor;
+ALTER TABLE "event" ADD COLUMN cursor BIGINT NOT NULL GENERATED ALWAYS AS (extract(epoch from created at time zone 'UTC') * 1000) STORED;
+
+CREATE INDEX "event_cursor_index" ON event (tenant_id, cursor);
@@ -0,0 +1,14 @@
+-- event cursor is a sequence instead of the creation millisecond so that
+-- events sharing a millisecond get distinct cursors in insertion order
+ALTER TABLE "event" DROP COLUMN cursor;
+ALTER TABLE "event" ADD COLUMN cursor BIGINT;
+
+UPDATE "event" SET cursor = numbered.seq FROM (
+  SELECT id, row_number() OVER (ORDER BY created, id) AS seq FROM "event"
+) AS numbered WHERE "event".id = numbered.id;
+
+ALTER TABLE "event" ALTER COLUMN cursor SET NOT NULL;
+ALTER TABLE "event" ALTER COLUMN cursor ADD GENERATED ALWAYS AS IDENTITY;
+SELECT setval(pg_get_serial_sequence('event', 'cursor'), (SELECT COALESCE(MAX(cursor), 0) + 1 FROM "event"), false);
+
+CREATE INDEX "event_cursor_index" ON event (tenant_id, cursor);
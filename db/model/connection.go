@@ -15,13 +15,16 @@ type Connections struct {
 
 type Connection struct {
 	Base
-	OurDid        string
-	TheirDid      string
-	TheirEndpoint string `faker:"url"`
-	TheirLabel    string `faker:"organisationLabel"`
-	Invited       bool
-	Approved      time.Time `faker:"-"`
-	Archived      time.Time `faker:"-"`
+	OurDid         string
+	TheirDid       string
+	TheirEndpoint  string `faker:"url"`
+	TheirLabel     string `faker:"organisationLabel"`
+	Invited        bool
+	Approved       time.Time `faker:"-"`
+	Archived       time.Time `faker:"-"`
+	LastSeen       time.Time `faker:"-"`
+	LastPing       time.Time `faker:"-"`
+	LastPingResult bool      `faker:"-"`
 }
 
 func (c *Connection) ToEdge() *model.PairwiseEdge {
@@ -33,15 +36,21 @@ func (c *Connection) ToEdge() *model.PairwiseEdge {
 }
 
 func (c *Connection) ToNode() *model.Pairwise {
+	var lastPingResult *bool
+	if !c.LastPing.IsZero() {
+		lastPingResult = &c.LastPingResult
+	}
 	return &model.Pairwise{
-		ID:            c.ID,
-		OurDid:        c.OurDid,
-		TheirDid:      c.TheirDid,
-		TheirEndpoint: c.TheirEndpoint,
-		TheirLabel:    c.TheirLabel,
-		CreatedMs:     timeToString(&c.Created),
-		ApprovedMs:    timeToString(&c.Approved),
-		Invited:       c.Invited,
+		ID:             c.ID,
+		OurDid:         c.OurDid,
+		TheirDid:       c.TheirDid,
+		TheirEndpoint:  c.TheirEndpoint,
+		TheirLabel:     c.TheirLabel,
+		CreatedMs:      timeToString(&c.Created),
+		ApprovedMs:     timeToString(&c.Approved),
+		Invited:        c.Invited,
+		LastSeenMs:     timeToStringPtr(&c.LastSeen),
+		LastPingResult: lastPingResult,
 	}
 }
 
@@ -69,6 +69,9 @@ func (c *Credential) Description() string {
 
 	switch c.Role {
 	case model.CredentialRoleIssuer:
+		if c.InitiatedByUs {
+			return "Sent credential offer"
+		}
 		return "Received credential request"
 	case model.CredentialRoleHolder:
 		return "Received credential offer"
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+// DeadLetter is an agency notification whose processing has failed.
+// Notification and status are stored in the agency wire format so that
+// the notification can be processed again later.
+type DeadLetter struct {
+	Base
+	ProtocolID     string
+	ProtocolType   model.ProtocolType
+	ConnectionID   string
+	Notification   []byte
+	ProtocolStatus []byte
+	Attempts       int
+	NextAttempt    time.Time
+	LastError      string
+}
+
+func (d *DeadLetter) ToNode() *model.DeadLetter {
+	var connectionID *string
+	if d.ConnectionID != "" {
+		connectionID = &d.ConnectionID
+	}
+	return &model.DeadLetter{
+		ID:            d.ID,
+		TenantID:      d.TenantID,
+		ProtocolID:    d.ProtocolID,
+		Protocol:      d.ProtocolType,
+		ConnectionID:  connectionID,
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		CreatedMs:     timeToString(&d.Created),
+		NextAttemptMs: timeToString(&d.NextAttempt),
+	}
+}
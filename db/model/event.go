@@ -19,6 +19,14 @@ type Event struct {
 	ConnectionID *string `faker:"-"`
 }
 
+// PublishedEvent tells the other vault replicas that an event was added
+type PublishedEvent struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenantId"`
+	// Origin identifies the replica that added the event
+	Origin string `json:"origin"`
+}
+
 func (e *Event) ToEdge() *model.EventEdge {
 	cursor := paginator.CreateCursor(e.Cursor, model.Event{})
 	return &model.EventEdge{
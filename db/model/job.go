@@ -25,6 +25,12 @@ type Job struct {
 	Result               model.JobResult    `faker:"oneof: SUCCESS,SUCCESS"`
 	InitiatedByUs        bool
 	Updated              time.Time
+	Expires              time.Time `faker:"-"`
+	Attempts             int       `faker:"-"`
+	FailureCode          string    `faker:"-"`
+	FailureReason        string    `faker:"-"`
+	// ProtocolID is the agency protocol started for a job queued in the outbox
+	ProtocolID *string `faker:"-"`
 }
 
 type JobOutput struct {
@@ -34,6 +40,21 @@ type JobOutput struct {
 	Message    *Message
 }
 
+// AgencyProtocolID returns the ID of the agency protocol tracked by the job.
+// Jobs queued in the outbox have their own ID and store the protocol ID once delivered.
+func (j *Job) AgencyProtocolID() string {
+	if j.ProtocolID != nil {
+		return *j.ProtocolID
+	}
+	return j.ID
+}
+
+// IsInvitation reports if the job waits for an invitation created by us to be used.
+// Such job has no protocol at agency, unlike the job connecting with the invitation of others.
+func (j *Job) IsInvitation() bool {
+	return j.ProtocolType == model.ProtocolTypeConnection && j.InitiatedByUs
+}
+
 func (j *Job) ToEdge() *model.JobEdge {
 	cursor := paginator.CreateCursor(j.Cursor, model.Job{})
 	return &model.JobEdge{
@@ -43,6 +64,13 @@ func (j *Job) ToEdge() *model.JobEdge {
 }
 
 func (j *Job) ToNode() *model.Job {
+	var failure *model.JobFailure
+	if j.FailureCode != "" || j.FailureReason != "" {
+		failure = &model.JobFailure{
+			Code:    j.FailureCode,
+			Message: j.FailureReason,
+		}
+	}
 	return &model.Job{
 		ID:        j.ID,
 		Protocol:  j.ProtocolType,
@@ -50,6 +78,9 @@ func (j *Job) ToNode() *model.Job {
 		Result:    j.Result,
 		CreatedMs: timeToString(&j.Created),
 		UpdatedMs: timeToString(&j.Updated),
+		ExpiresMs: timeToStringPtr(&j.Expires),
+		Attempts:  j.Attempts,
+		Failure:   failure,
 	}
 }
 
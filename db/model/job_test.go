@@ -0,0 +1,22 @@
+package model
+
+import "testing"
+
+func TestAgencyProtocolID(t *testing.T) {
+	protocolID := "protocol-id"
+	tests := []struct {
+		name string
+		job  *Job
+		exp  string
+	}{
+		{"protocol job", &Job{Base: Base{ID: "job-id"}}, "job-id"},
+		{"delivered outbox job", &Job{Base: Base{ID: "job-id"}, ProtocolID: &protocolID}, protocolID},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.job.AgencyProtocolID(); got != tc.exp {
+				t.Errorf("Mismatch in protocol ID, expected: %s got: %s", tc.exp, got)
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ type Message struct {
 	ConnectionID string
 	Message      string `faker:"sentence"`
 	SentByMe     bool
-	Delivered    bool
+	Delivered    *bool     `faker:"-"`
 	Archived     time.Time `faker:"-"`
 }
 
@@ -35,13 +35,19 @@ func (m *Message) ToNode() *model.BasicMessage {
 		ID:        m.ID,
 		Message:   m.Message,
 		SentByMe:  m.SentByMe,
-		Delivered: nil, // TODO: linter needs coment, implement later
+		Delivered: m.Delivered,
 		CreatedMs: timeToString(&m.Created),
 	}
 }
 
 func (m *Message) Description() string {
 	if m.SentByMe {
+		if m.Delivered != nil {
+			if *m.Delivered {
+				return "Delivered basic message"
+			}
+			return "Failed to deliver basic message"
+		}
 		return "Sent basic message"
 	}
 	return "Received basic message"
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+// OutboxItem is an outgoing agency operation that is delivered asynchronously.
+// The item ID is used as the ID of the job tracking the delivery.
+type OutboxItem struct {
+	Base
+	ConnectionID string
+	ProtocolType model.ProtocolType
+	Payload      []byte
+	Attempts     int
+	NextAttempt  time.Time
+	LastError    string
+	Delivered    time.Time
+	Failed       time.Time
+}
+
+func (o *OutboxItem) IsPending() bool {
+	return o.Delivered.IsZero() && o.Failed.IsZero()
+}
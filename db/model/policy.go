@@ -0,0 +1,88 @@
+package model
+
+import (
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+type Policy struct {
+	Base
+	ProtocolType model.ProtocolType `faker:"oneof: CREDENTIAL,PROOF"`
+	Action       model.PolicyAction `faker:"oneof: ACCEPT,DECLINE"`
+	ConnectionID string             `faker:"-"`
+	TheirLabel   string             `faker:"-"`
+	TheirDid     string             `faker:"-"`
+	CredDefID    string             `faker:"-"`
+	Attributes   []string           `faker:"-"`
+}
+
+// PolicyTarget describes the incoming credential offer or proof request
+// the policies are evaluated against.
+type PolicyTarget struct {
+	ProtocolType model.ProtocolType
+	ConnectionID string
+	TheirLabel   string
+	TheirDid     string
+	// CredDefIDs contains the cred def of the offer or the restrictions of requested attributes
+	CredDefIDs []string
+	Attributes []string
+}
+
+func (p *Policy) ToNode() *model.TenantPolicy {
+	attributes := p.Attributes
+	if attributes == nil {
+		attributes = make([]string, 0)
+	}
+	return &model.TenantPolicy{
+		ID:           p.ID,
+		Protocol:     p.ProtocolType,
+		Action:       p.Action,
+		ConnectionID: p.ConnectionID,
+		TheirLabel:   p.TheirLabel,
+		TheirDid:     p.TheirDid,
+		CredDefID:    p.CredDefID,
+		Attributes:   attributes,
+		CreatedMs:    timeToString(&p.Created),
+	}
+}
+
+// IsEmpty reports if the policy has no conditions besides the protocol type
+func (p *Policy) IsEmpty() bool {
+	return p.ConnectionID == "" && p.TheirLabel == "" && p.TheirDid == "" &&
+		p.CredDefID == "" && len(p.Attributes) == 0
+}
+
+// Matches checks if policy applies to the target. All policy fields that are set must match.
+// Cred def must match for all target cred defs and target attributes must be
+// a subset of the policy attributes. Policy without conditions matches nothing.
+func (p *Policy) Matches(target *PolicyTarget) bool {
+	if p.ProtocolType != target.ProtocolType || p.IsEmpty() {
+		return false
+	}
+	if (p.ConnectionID != "" && p.ConnectionID != target.ConnectionID) ||
+		(p.TheirLabel != "" && p.TheirLabel != target.TheirLabel) ||
+		(p.TheirDid != "" && p.TheirDid != target.TheirDid) {
+		return false
+	}
+	if p.CredDefID != "" {
+		if len(target.CredDefIDs) == 0 {
+			return false
+		}
+		for _, credDefID := range target.CredDefIDs {
+			if credDefID != p.CredDefID {
+				return false
+			}
+		}
+	}
+	if len(p.Attributes) > 0 {
+		allowed := make(map[string]bool)
+		for _, name := range p.Attributes {
+			allowed[name] = true
+		}
+		for _, name := range target.Attributes {
+			if !allowed[name] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestPolicyMatches(t *testing.T) {
+	target := &PolicyTarget{
+		ProtocolType: model.ProtocolTypeCredential,
+		ConnectionID: "connection-id",
+		TheirLabel:   "label",
+		TheirDid:     "did",
+		CredDefIDs:   []string{"cred-def-id"},
+		Attributes:   []string{"name", "email"},
+	}
+	tests := []struct {
+		name   string
+		policy *Policy
+		exp    bool
+	}{
+		{"protocol only", &Policy{ProtocolType: model.ProtocolTypeCredential}, false},
+		{"connection only", &Policy{ProtocolType: model.ProtocolTypeCredential, ConnectionID: "connection-id"}, true},
+		{"protocol mismatch", &Policy{ProtocolType: model.ProtocolTypeProof}, false},
+		{
+			"all fields",
+			&Policy{
+				ProtocolType: model.ProtocolTypeCredential,
+				ConnectionID: "connection-id",
+				TheirLabel:   "label",
+				TheirDid:     "did",
+				CredDefID:    "cred-def-id",
+				Attributes:   []string{"name", "email", "phone"},
+			},
+			true,
+		},
+		{"connection mismatch", &Policy{ProtocolType: model.ProtocolTypeCredential, ConnectionID: "other"}, false},
+		{"label mismatch", &Policy{ProtocolType: model.ProtocolTypeCredential, TheirLabel: "other"}, false},
+		{"did mismatch", &Policy{ProtocolType: model.ProtocolTypeCredential, TheirDid: "other"}, false},
+		{"cred def mismatch", &Policy{ProtocolType: model.ProtocolTypeCredential, CredDefID: "other"}, false},
+		{"attribute not allowed", &Policy{ProtocolType: model.ProtocolTypeCredential, Attributes: []string{"name"}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Matches(target); got != tc.exp {
+				t.Errorf("Mismatch for policy match, expected %v got %v", tc.exp, got)
+			}
+		})
+	}
+}
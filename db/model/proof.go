@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/findy-network/findy-agent-vault/graph/model"
@@ -19,6 +20,7 @@ type Proof struct {
 	ConnectionID  string
 	Role          model.ProofRole         `faker:"oneof: PROVER, PROVER"`
 	Attributes    []*model.ProofAttribute `faker:"proofAttributes"`
+	Predicates    []*model.ProofPredicate `faker:"-"`
 	Values        []*model.ProofValue     `faker:"-"`
 	InitiatedByUs bool
 	Result        bool
@@ -29,6 +31,58 @@ type Proof struct {
 	Archived      time.Time `faker:"-"`
 }
 
+const (
+	schemaIDPartCount = 4
+	idSeparator       = ":"
+)
+
+// schemaIDParts splits schema id of format <issuer did>:2:<name>:<version>
+func schemaIDParts(schemaID string) (issuerDID, name string) {
+	parts := strings.Split(schemaID, idSeparator)
+	if len(parts) != schemaIDPartCount {
+		return "", ""
+	}
+	return parts[0], parts[2]
+}
+
+// credDefIssuer picks issuer did from cred def id of format <issuer did>:3:CL:<schema>:<tag>
+func credDefIssuer(credDefID string) string {
+	return strings.Split(credDefID, idSeparator)[0]
+}
+
+func restrictionMatches(r *model.ProofRestriction, schemaID, credDefID string) bool {
+	schemaMatches := true
+	if schemaID != "" {
+		schemaIssuerDID, schemaName := schemaIDParts(schemaID)
+		schemaMatches = (r.SchemaID == "" || r.SchemaID == schemaID) &&
+			(r.SchemaIssuerDid == "" || r.SchemaIssuerDid == schemaIssuerDID) &&
+			(r.SchemaName == "" || r.SchemaName == schemaName)
+	}
+	return schemaMatches &&
+		(r.IssuerDid == "" || r.IssuerDid == credDefIssuer(credDefID)) &&
+		(r.CredDefID == "" || r.CredDefID == credDefID)
+}
+
+// AttributeMatchesCredential checks if credential with given schema and cred def ids
+// can be used to prove the attribute. All fields of a single restriction must match
+// and at least one of the restrictions must match. Empty schema id skips the schema
+// fields of the restrictions, e.g. values of a verified proof carry only the cred def id
+// and the agency has already checked the schema restrictions when verifying the proof.
+func AttributeMatchesCredential(attr *model.ProofAttribute, schemaID, credDefID string) bool {
+	if attr.CredDefID != "" && attr.CredDefID != credDefID {
+		return false
+	}
+	if len(attr.Restrictions) == 0 {
+		return true
+	}
+	for _, r := range attr.Restrictions {
+		if restrictionMatches(r, schemaID, credDefID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Proof) ToEdge() *model.ProofEdge {
 	cursor := paginator.CreateCursor(p.Cursor, model.Proof{})
 	return &model.ProofEdge{
@@ -38,10 +92,15 @@ func (p *Proof) ToEdge() *model.ProofEdge {
 }
 
 func (p *Proof) ToNode() *model.Proof {
+	predicates := p.Predicates
+	if predicates == nil {
+		predicates = make([]*model.ProofPredicate, 0)
+	}
 	return &model.Proof{
 		ID:            p.ID,
 		Role:          p.Role,
 		Attributes:    p.Attributes,
+		Predicates:    predicates,
 		Values:        p.Values,
 		InitiatedByUs: p.InitiatedByUs,
 		Result:        p.Result,
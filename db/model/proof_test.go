@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestAttributeMatchesCredential(t *testing.T) {
+	const (
+		schemaID  = "schemaIssuer:2:schemaName:1.0"
+		credDefID = "issuer:3:CL:12:tag"
+	)
+	tests := []struct {
+		name string
+		attr *model.ProofAttribute
+		exp  bool
+	}{
+		{"no restrictions", &model.ProofAttribute{}, true},
+		{"cred def", &model.ProofAttribute{CredDefID: credDefID}, true},
+		{"cred def mismatch", &model.ProofAttribute{CredDefID: "other"}, false},
+		{
+			"all fields",
+			&model.ProofAttribute{Restrictions: []*model.ProofRestriction{{
+				SchemaID:        schemaID,
+				SchemaIssuerDid: "schemaIssuer",
+				SchemaName:      "schemaName",
+				IssuerDid:       "issuer",
+				CredDefID:       credDefID,
+			}}},
+			true,
+		},
+		{
+			"and mismatch",
+			&model.ProofAttribute{Restrictions: []*model.ProofRestriction{{IssuerDid: "issuer", SchemaName: "other"}}},
+			false,
+		},
+		{
+			"or match",
+			&model.ProofAttribute{Restrictions: []*model.ProofRestriction{{IssuerDid: "other"}, {SchemaIssuerDid: "schemaIssuer"}}},
+			true,
+		},
+		{
+			"or mismatch",
+			&model.ProofAttribute{Restrictions: []*model.ProofRestriction{{IssuerDid: "other"}, {SchemaID: "other"}}},
+			false,
+		},
+		{
+			"cred def and restriction mismatch",
+			&model.ProofAttribute{CredDefID: credDefID, Restrictions: []*model.ProofRestriction{{IssuerDid: "other"}}},
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AttributeMatchesCredential(tc.attr, schemaID, credDefID); got != tc.exp {
+				t.Errorf("Mismatch for attribute match, expected %v got %v", tc.exp, got)
+			}
+		})
+	}
+}
+
+func TestAttributeMatchesCredentialWithoutSchema(t *testing.T) {
+	const credDefID = "issuer:3:CL:12:tag"
+	tests := []struct {
+		name string
+		attr *model.ProofAttribute
+		exp  bool
+	}{
+		{"schema", &model.ProofAttribute{Restrictions: []*model.ProofRestriction{{SchemaID: "schema"}}}, true},
+		{"schema and issuer", &model.ProofAttribute{Restrictions: []*model.ProofRestriction{{SchemaName: "name", IssuerDid: "issuer"}}}, true},
+		{"issuer mismatch", &model.ProofAttribute{Restrictions: []*model.ProofRestriction{{SchemaName: "name", IssuerDid: "other"}}}, false},
+		{"cred def mismatch", &model.ProofAttribute{CredDefID: "other"}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AttributeMatchesCredential(tc.attr, "", credDefID); got != tc.exp {
+				t.Errorf("Mismatch for attribute match, expected %v got %v", tc.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+// Replica is a live vault replica
+type Replica struct {
+	ID string
+	// Ring identifies the replica set whose tenant assignment the replica has applied
+	Ring string
+}
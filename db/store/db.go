@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/db/model"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
@@ -58,12 +59,14 @@ func GetAgent(ctx context.Context, db DB) (*model.Agent, error) {
 //nolint:interfacebloat
 type DB interface {
 	GetListenerAgents(info *paginator.BatchInfo) (*model.Agents, error)
+	GetAccessedAgents(since time.Time) ([]*model.Agent, error)
 	Close()
 
 	AddAgent(a *model.Agent) (*model.Agent, error)
 	GetAgent(id, agentID *string) (*model.Agent, error)
 
 	AddConnection(c *model.Connection) (*model.Connection, error)
+	UpdateConnection(c *model.Connection) (*model.Connection, error)
 	GetConnection(id, tenantID string) (*model.Connection, error)
 	GetConnections(info *paginator.BatchInfo, tenantID string) (*model.Connections, error)
 	GetConnectionCount(tenantID string) (int, error)
@@ -101,6 +104,8 @@ type DB interface {
 	GetEventCount(tenantID string, connectionID *string) (int, error)
 	GetConnectionForEvent(id, tenantID string) (*model.Connection, error)
 	GetJobForEvent(id, tenantID string) (*model.Job, error)
+	PublishEvent(ctx context.Context, event *model.PublishedEvent) error
+	ListenEvents(ctx context.Context, pingInterval time.Duration) (<-chan *model.PublishedEvent, error)
 	GetJobOutput(id, tenantID string, protocolType graph.ProtocolType) (*model.JobOutput, error)
 
 	AddJob(j *model.Job) (*model.Job, error)
@@ -109,5 +114,46 @@ type DB interface {
 	GetJobs(info *paginator.BatchInfo, tenantID string, connectionID *string, completed *bool) (*model.Jobs, error)
 	GetJobCount(tenantID string, connectionID *string, completed *bool) (int, error)
 	GetConnectionForJob(id, tenantID string) (*model.Connection, error)
+	GetExpiredJobs(before time.Time, count int) ([]*model.Job, error)
 	GetOpenProofJobs(tenantID string, proofAttributes []*graph.ProofAttribute) ([]*model.Job, error)
+	LinkJob(id, protocolID, tenantID string) (*model.Job, error)
+
+	AddOutboxItem(o *model.OutboxItem) (*model.OutboxItem, error)
+	UpdateOutboxItem(o *model.OutboxItem) (*model.OutboxItem, error)
+	GetOutboxItem(id, tenantID string) (*model.OutboxItem, error)
+	ClaimDueOutboxItem(before, leaseUntil time.Time) (*model.OutboxItem, error)
+
+	AddDeadLetter(d *model.DeadLetter) (*model.DeadLetter, error)
+	UpdateDeadLetter(d *model.DeadLetter) (*model.DeadLetter, error)
+	GetDeadLetter(id string) (*model.DeadLetter, error)
+	GetDeadLetters(tenantID *string) ([]*model.DeadLetter, error)
+	ClaimDueDeadLetter(before, leaseUntil time.Time, maxAttempts int) (*model.DeadLetter, error)
+	ClaimDeadLetter(id string, leaseUntil time.Time) (*model.DeadLetter, error)
+	RemoveDeadLetter(id, tenantID string) error
+
+	ClaimNotification(tenantID, protocolID, state string, leaseUntil time.Time) (bool, error)
+	MarkNotificationProcessed(tenantID, protocolID, state string) error
+	ReleaseNotification(tenantID, protocolID, state string) error
+	DeleteProcessedNotifications(before time.Time) (int64, error)
+
+	AddPolicy(p *model.Policy) (*model.Policy, error)
+	UpdatePolicy(p *model.Policy) (*model.Policy, error)
+	GetPolicies(tenantID string) ([]*model.Policy, error)
+	RemovePolicy(id, tenantID string) error
+
+	TryLock(ctx context.Context, key int64, holder string) (Lock, error)
+	GetLockHolder(ctx context.Context, key int64) (string, error)
+
+	UpdateReplica(ctx context.Context, id, ring string) error
+	GetReplicas(ctx context.Context, timeout time.Duration) ([]*model.Replica, error)
+	RemoveReplica(ctx context.Context, id string) error
+}
+
+// Lock is a database lock held by this instance
+type Lock interface {
+	// Check verifies that the lock is still held
+	Check(ctx context.Context) error
+	// Lost is closed when the connection holding the lock is found broken
+	Lost() <-chan struct{}
+	Release() error
 }
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/lainio/err2"
 	"github.com/lainio/err2/try"
@@ -81,6 +83,27 @@ func (pg *Database) GetListenerAgents(info *paginator.BatchInfo) (a *model.Agent
 	return a, err
 }
 
+// GetAccessedAgents returns the listener agents that have accessed the vault after the given time
+func (pg *Database) GetAccessedAgents(since time.Time) (agents []*model.Agent, err error) {
+	defer err2.Handle(&err, "GetAccessedAgents")
+
+	sqlAgentSelectAccessed := sqlAgentSelect + " WHERE last_accessed > $1 AND" + sqlJwtNotSet
+
+	agents = make([]*model.Agent, 0)
+	err = pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		defer err2.Handle(&err)
+		agent := try.To1(rowToAgent(rows))
+		agents = append(agents, agent)
+		return
+	}, sqlAgentSelectAccessed, since)
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		err = nil
+	}
+	try.To(err)
+
+	return agents, nil
+}
+
 func (pg *Database) AddAgent(a *model.Agent) (newAgent *model.Agent, err error) {
 	defer err2.Handle(&err, "AddAgent")
 
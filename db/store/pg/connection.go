@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	connectionFields        = []string{"id", "tenant_id", "our_did", "their_did", "their_endpoint", "their_label", "invited", "archived"}
+	connectionFields = []string{"id", "tenant_id", "our_did", "their_did", "their_endpoint", "their_label", "invited", "archived",
+		"last_seen", "last_ping", "last_ping_result"}
 	connectionExtraFields   = []string{"created", "approved", "cursor"}
 	sqlConnectionBaseFields = sqlFields("", connectionFields)
 	sqlConnectionInsert     = "INSERT INTO connection " + "(" + sqlConnectionBaseFields + ") " +
@@ -69,6 +70,9 @@ func (pg *Database) AddConnection(c *model.Connection) (newConnection *model.Con
 		c.TheirLabel,
 		c.Invited,
 		c.Archived,
+		c.LastSeen,
+		c.LastPing,
+		c.LastPingResult,
 	))
 
 	return
@@ -90,6 +94,9 @@ func readRowToConnection(c *model.Connection) func(*sql.Rows) error {
 			&c.TheirLabel,
 			&c.Invited,
 			&c.Archived,
+			&c.LastSeen,
+			&c.LastPing,
+			&c.LastPingResult,
 			&c.Created,
 			&c.Approved,
 			&c.Cursor,
@@ -98,6 +105,25 @@ func readRowToConnection(c *model.Connection) func(*sql.Rows) error {
 	}
 }
 
+func (pg *Database) UpdateConnection(arg *model.Connection) (c *model.Connection, err error) {
+	defer err2.Handle(&err, "UpdateConnection")
+
+	sqlConnectionUpdate := "UPDATE connection SET last_seen=$1, last_ping=$2, last_ping_result=$3" +
+		" WHERE id = $4 AND tenant_id = $5 RETURNING " + sqlConnectionBaseFields + "," + sqlFields("", connectionExtraFields)
+
+	c = &model.Connection{}
+	try.To(pg.doRowQuery(
+		readRowToConnection(c),
+		sqlConnectionUpdate,
+		arg.LastSeen,
+		arg.LastPing,
+		arg.LastPingResult,
+		arg.ID,
+		arg.TenantID,
+	))
+	return
+}
+
 func (pg *Database) GetConnection(id, tenantID string) (c *model.Connection, err error) {
 	defer err2.Handle(&err, "GetConnection")
 
@@ -363,26 +363,24 @@ func (pg *Database) SearchCredentials(
 
 	assert.That(len(proofAttributes) > 0, "cannot search credentials for empty proof")
 
-	credDefIDs := make([]string, 0)
 	names := make([]string, 0)
 	for _, attr := range proofAttributes {
-		if attr.CredDefID != "" {
-			credDefIDs = append(credDefIDs, attr.CredDefID)
-		}
 		names = append(names, attr.Name)
 	}
 
-	utils.LogMed().Infof("Searching for credentials with cred def id %v, name %v", credDefIDs, names)
+	utils.LogMed().Infof("Searching for credentials with name %v", names)
 
+	// restrictions are matched after the query as they are partly encoded in schema and cred def ids
 	var (
-		sqlCredentialSearch = "SELECT credential.id, name, cred_def_id, value FROM credential " + sqlCredentialJoin +
-			" WHERE tenant_id=$1 AND issued > timestamp '0001-01-01' AND (cred_def_id=ANY($2::varchar[]) OR name=ANY($3::varchar[]))" +
+		sqlCredentialSearch = "SELECT credential.id, name, schema_id, cred_def_id, value FROM credential " + sqlCredentialJoin +
+			" WHERE tenant_id=$1 AND issued > timestamp '0001-01-01' AND name=ANY($2::varchar[])" +
 			" ORDER BY credential.created"
 	)
 
 	type searchResult struct {
 		credID    string
 		attrName  string
+		schemaID  string
 		credDefID string
 		credValue string
 	}
@@ -390,10 +388,10 @@ func (pg *Database) SearchCredentials(
 	try.To(pg.doRowsQuery(func(rows *sql.Rows) (err error) {
 		defer err2.Handle(&err)
 		s := &searchResult{}
-		try.To(rows.Scan(&s.credID, &s.attrName, &s.credDefID, &s.credValue))
+		try.To(rows.Scan(&s.credID, &s.attrName, &s.schemaID, &s.credDefID, &s.credValue))
 		searchResults = append(searchResults, s)
 		return
-	}, sqlCredentialSearch, tenantID, pq.Array(credDefIDs), pq.Array(names)))
+	}, sqlCredentialSearch, tenantID, pq.Array(names)))
 
 	res = make([]*graph.ProvableAttribute, 0)
 	for _, attr := range proofAttributes {
@@ -402,7 +400,7 @@ func (pg *Database) SearchCredentials(
 		provableAttr.Attribute = attr
 		provableAttr.Credentials = make([]*graph.CredentialMatch, 0)
 		for _, value := range searchResults {
-			if value.attrName == attr.Name && (attr.CredDefID == "" || attr.CredDefID == value.credDefID) {
+			if value.attrName == attr.Name && model.AttributeMatchesCredential(attr, value.schemaID, value.credDefID) {
 				provableAttr.Credentials = append(provableAttr.Credentials, &graph.CredentialMatch{
 					ID:           attr.ID + "/" + value.credID,
 					CredentialID: value.credID,
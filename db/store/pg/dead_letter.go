@@ -0,0 +1,189 @@
+package pg
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+var (
+	deadLetterFields = []string{"tenant_id", "protocol_id", "protocol_type", "connection_id", "notification",
+		"protocol_status", "attempts", "next_attempt", "last_error"}
+
+	sqlBaseDeadLetterFields = sqlFields("", deadLetterFields)
+	sqlDeadLetterSelect     = "SELECT id, " + sqlBaseDeadLetterFields + ", created, cursor FROM"
+)
+
+func readRowToDeadLetter(d *model.DeadLetter) func(*sql.Rows) error {
+	return func(rows *sql.Rows) error {
+		return rows.Scan(
+			&d.ID,
+			&d.TenantID,
+			&d.ProtocolID,
+			&d.ProtocolType,
+			&d.ConnectionID,
+			&d.Notification,
+			&d.ProtocolStatus,
+			&d.Attempts,
+			&d.NextAttempt,
+			&d.LastError,
+			&d.Created,
+			&d.Cursor,
+		)
+	}
+}
+
+func (pg *Database) getDeadLetters(query string, args ...interface{}) (letters []*model.DeadLetter, err error) {
+	defer err2.Handle(&err)
+
+	letters = make([]*model.DeadLetter, 0)
+	if err = pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		letter := &model.DeadLetter{}
+		err = readRowToDeadLetter(letter)(rows)
+		letters = append(letters, letter)
+		return err
+	}, query, args...); err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		err = nil
+	}
+	try.To(err)
+
+	return letters, nil
+}
+
+func (pg *Database) AddDeadLetter(arg *model.DeadLetter) (d *model.DeadLetter, err error) {
+	defer err2.Handle(&err, "AddDeadLetter")
+
+	var (
+		sqlDeadLetterInsert = "INSERT INTO dead_letter " + "(" + sqlBaseDeadLetterFields + ") " +
+			"VALUES (" + sqlArguments(deadLetterFields) + ") RETURNING " + sqlInsertFields
+	)
+
+	d = &model.DeadLetter{}
+	*d = *arg
+	if d.NextAttempt.IsZero() {
+		d.NextAttempt = time.Now().UTC()
+	}
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&d.ID, &d.Created, &d.Cursor)
+		},
+		sqlDeadLetterInsert,
+		d.TenantID,
+		d.ProtocolID,
+		d.ProtocolType,
+		d.ConnectionID,
+		d.Notification,
+		d.ProtocolStatus,
+		d.Attempts,
+		d.NextAttempt,
+		d.LastError,
+	))
+
+	return d, err
+}
+
+func (pg *Database) UpdateDeadLetter(arg *model.DeadLetter) (d *model.DeadLetter, err error) {
+	defer err2.Handle(&err, "UpdateDeadLetter")
+
+	// update ends the attempt, the claim is released
+	sqlDeadLetterUpdate := "UPDATE dead_letter SET attempts=$1, next_attempt=$2, last_error=$3," +
+		" claimed_until=timestamp '0001-01-01'" +
+		" WHERE id = $4 AND tenant_id = $5" +
+		" RETURNING id," + sqlBaseDeadLetterFields + ", created, cursor"
+
+	d = &model.DeadLetter{}
+	try.To(pg.doRowQuery(
+		readRowToDeadLetter(d),
+		sqlDeadLetterUpdate,
+		arg.Attempts,
+		arg.NextAttempt,
+		arg.LastError,
+		arg.ID,
+		arg.TenantID,
+	))
+	return d, err
+}
+
+func (pg *Database) GetDeadLetter(id string) (d *model.DeadLetter, err error) {
+	defer err2.Handle(&err, "GetDeadLetter")
+
+	sqlDeadLetterSelectByID := sqlDeadLetterSelect + " dead_letter WHERE id=$1"
+
+	d = &model.DeadLetter{}
+	try.To(pg.doRowQuery(
+		readRowToDeadLetter(d),
+		sqlDeadLetterSelectByID,
+		id,
+	))
+	return d, err
+}
+
+// GetDeadLetters returns the letters of the tenant or all letters if tenant is not given
+func (pg *Database) GetDeadLetters(tenantID *string) (letters []*model.DeadLetter, err error) {
+	defer err2.Handle(&err, "GetDeadLetters")
+
+	if tenantID == nil {
+		return pg.getDeadLetters(sqlDeadLetterSelect + " dead_letter ORDER BY cursor ASC")
+	}
+	sqlDeadLetterSelectByTenant := sqlDeadLetterSelect + " dead_letter WHERE tenant_id=$1 ORDER BY cursor ASC"
+	return pg.getDeadLetters(sqlDeadLetterSelectByTenant, *tenantID)
+}
+
+// ClaimDueDeadLetter leases the oldest due letter to the caller until leaseUntil.
+// The row lock is skipped by concurrent claimers, so each letter is claimed by one worker at a time.
+func (pg *Database) ClaimDueDeadLetter(before, leaseUntil time.Time, maxAttempts int) (d *model.DeadLetter, err error) {
+	defer err2.Handle(&err, "ClaimDueDeadLetter")
+
+	const sqlDeadLetterClaimDue = "UPDATE dead_letter SET claimed_until=$2 WHERE id = (" +
+		"SELECT id FROM dead_letter" +
+		" WHERE next_attempt <= $1 AND claimed_until <= $1 AND attempts < $3" +
+		" ORDER BY next_attempt ASC LIMIT 1 FOR UPDATE SKIP LOCKED)"
+
+	d = &model.DeadLetter{}
+	try.To(pg.doRowQuery(
+		readRowToDeadLetter(d),
+		sqlDeadLetterClaimDue+" RETURNING id,"+sqlBaseDeadLetterFields+", created, cursor",
+		before,
+		leaseUntil,
+		maxAttempts,
+	))
+	return d, err
+}
+
+// ClaimDeadLetter leases the letter to the caller until leaseUntil regardless of its retry schedule.
+// Fails with not found if the letter is claimed by someone else.
+func (pg *Database) ClaimDeadLetter(id string, leaseUntil time.Time) (d *model.DeadLetter, err error) {
+	defer err2.Handle(&err, "ClaimDeadLetter")
+
+	const sqlDeadLetterClaim = "UPDATE dead_letter SET claimed_until=$2" +
+		" WHERE id=$1 AND claimed_until <= (now() at time zone 'UTC')"
+
+	d = &model.DeadLetter{}
+	try.To(pg.doRowQuery(
+		readRowToDeadLetter(d),
+		sqlDeadLetterClaim+" RETURNING id,"+sqlBaseDeadLetterFields+", created, cursor",
+		id,
+		leaseUntil,
+	))
+	return d, err
+}
+
+func (pg *Database) RemoveDeadLetter(id, tenantID string) (err error) {
+	defer err2.Handle(&err, "RemoveDeadLetter")
+
+	const sqlDeadLetterDelete = "DELETE FROM dead_letter WHERE id = $1 AND tenant_id = $2 RETURNING id"
+
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&id)
+		},
+		sqlDeadLetterDelete,
+		id,
+		tenantID,
+	))
+	return
+}
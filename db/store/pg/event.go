@@ -137,7 +137,7 @@ func (pg *Database) getEventsForQuery(
 	// Reverse order for tail first
 	if batch.Tail {
 		sort.Slice(e.Events, func(i, j int) bool {
-			return e.Events[i].Created.Sub(e.Events[j].Created) < 0
+			return e.Events[i].Cursor < e.Events[j].Cursor
 		})
 	}
 
@@ -3,8 +3,10 @@ package pg
 import (
 	"database/sql"
 	"sort"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/lainio/err2"
@@ -14,10 +16,10 @@ import (
 
 var (
 	jobFields = []string{"id", "tenant_id", "protocol_type", "protocol_connection_id", "protocol_credential_id", "protocol_proof_id",
-		"protocol_message_id", "connection_id", "status", "result", "initiated_by_us", "updated"}
+		"protocol_message_id", "connection_id", "status", "result", "initiated_by_us", "failure_code", "failure_reason", "updated", "expires", "attempts", "protocol_id"}
 	sqlJobBaseFields = sqlFields("", jobFields)
 	sqlJobInsert     = "INSERT INTO job " + "(" + sqlJobBaseFields + ") " +
-		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, (now() at time zone 'UTC')) RETURNING " + sqlInsertFields
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, (now() at time zone 'UTC'), $14, $15, $16) RETURNING " + sqlInsertFields
 	sqlJobSelect = "SELECT " + sqlJobBaseFields + ", created, cursor FROM"
 )
 
@@ -61,6 +63,11 @@ func (pg *Database) AddJob(j *model.Job) (job *model.Job, err error) {
 		j.Status,
 		j.Result,
 		j.InitiatedByUs,
+		j.FailureCode,
+		j.FailureReason,
+		j.Expires,
+		j.Attempts,
+		j.ProtocolID,
 	))
 
 	return job, err
@@ -71,8 +78,9 @@ func (pg *Database) UpdateJob(arg *model.Job) (j *model.Job, err error) {
 
 	sqlJobUpdate := "UPDATE job " +
 		"SET protocol_connection_id=$1, protocol_credential_id=$2, protocol_proof_id=$3, protocol_message_id=$4," +
-		" connection_id=$5, status=$6, result=$7, updated=(now() at time zone 'UTC')" +
-		" WHERE id = $8 AND tenant_id = $9" +
+		" connection_id=$5, status=$6, result=$7, failure_code=$8, failure_reason=$9, updated=(now() at time zone 'UTC')," +
+		" expires=$10, attempts=$11" +
+		" WHERE id = $12 AND tenant_id = $13" +
 		" RETURNING " + sqlJobBaseFields + ", created, cursor"
 
 	j = &model.Job{}
@@ -86,12 +94,71 @@ func (pg *Database) UpdateJob(arg *model.Job) (j *model.Job, err error) {
 		arg.ConnectionID,
 		arg.Status,
 		arg.Result,
+		arg.FailureCode,
+		arg.FailureReason,
+		arg.Expires,
+		arg.Attempts,
 		arg.ID,
 		arg.TenantID,
 	))
 	return j, err
 }
 
+func (pg *Database) GetExpiredJobs(before time.Time, count int) (jobs []*model.Job, err error) {
+	defer err2.Handle(&err, "GetExpiredJobs")
+
+	sqlJobSelectExpired := sqlJobSelect +
+		" job WHERE status = 'WAITING' AND expires > timestamp '0001-01-01' AND expires < $1" +
+		" ORDER BY expires ASC LIMIT $2"
+
+	jobs = make([]*model.Job, 0)
+	err = pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		defer err2.Handle(&err)
+		job := try.To1(rowToJob(rows))
+		jobs = append(jobs, job)
+		return
+	}, sqlJobSelectExpired, before, count)
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		err = nil
+	}
+	try.To(err)
+
+	return jobs, nil
+}
+
+// LinkJob links job with the agency protocol started for it: the job is found with the protocol ID
+// from now on and the job possibly recorded for the protocol is merged into it, keeping the original job ID.
+func (pg *Database) LinkJob(id, protocolID, tenantID string) (j *model.Job, err error) {
+	defer err2.Handle(&err, "LinkJob")
+
+	const sqlJobLink = "WITH protocol_job AS (" +
+		"DELETE FROM job WHERE id=$2 AND tenant_id=$3 RETURNING *), " +
+		"moved_events AS (" +
+		"UPDATE event SET job_id=$1 WHERE job_id=$2 AND tenant_id=$3) " +
+		"UPDATE job SET protocol_id=$2," +
+		" protocol_connection_id=COALESCE((SELECT protocol_connection_id FROM protocol_job), job.protocol_connection_id)," +
+		" protocol_credential_id=COALESCE((SELECT protocol_credential_id FROM protocol_job), job.protocol_credential_id)," +
+		" protocol_proof_id=COALESCE((SELECT protocol_proof_id FROM protocol_job), job.protocol_proof_id)," +
+		" protocol_message_id=COALESCE((SELECT protocol_message_id FROM protocol_job), job.protocol_message_id)," +
+		" status=COALESCE((SELECT status FROM protocol_job), job.status)," +
+		" result=COALESCE((SELECT result FROM protocol_job), job.result)," +
+		" failure_code=COALESCE((SELECT failure_code FROM protocol_job), job.failure_code)," +
+		" failure_reason=COALESCE((SELECT failure_reason FROM protocol_job), job.failure_reason)," +
+		" expires=COALESCE((SELECT expires FROM protocol_job), job.expires)," +
+		" updated=(now() at time zone 'UTC')" +
+		" WHERE id=$1 AND tenant_id=$3"
+
+	j = &model.Job{}
+	try.To(pg.doRowQuery(
+		readRowToJob(j),
+		sqlJobLink+" RETURNING "+sqlJobBaseFields+", created, cursor",
+		id,
+		protocolID,
+		tenantID,
+	))
+	return j, err
+}
+
 func rowToJob(rows *sql.Rows) (n *model.Job, err error) {
 	n = &model.Job{}
 	return n, readRowToJob(n)(rows)
@@ -111,7 +178,12 @@ func readRowToJob(n *model.Job) func(*sql.Rows) error {
 			&n.Status,
 			&n.Result,
 			&n.InitiatedByUs,
+			&n.FailureCode,
+			&n.FailureReason,
 			&n.Updated,
+			&n.Expires,
+			&n.Attempts,
+			&n.ProtocolID,
 			&n.Created,
 			&n.Cursor,
 		)
@@ -121,7 +193,8 @@ func readRowToJob(n *model.Job) func(*sql.Rows) error {
 func (pg *Database) GetJob(id, tenantID string) (job *model.Job, err error) {
 	defer err2.Handle(&err, "GetJob")
 
-	sqlJobSelectByID := sqlJobSelect + " job WHERE id=$1 AND tenant_id=$2"
+	// jobs started through the outbox are found also with the ID of the agency protocol
+	sqlJobSelectByID := sqlJobSelect + " job WHERE (id=$1 OR protocol_id=$1) AND tenant_id=$2"
 
 	job = &model.Job{}
 	try.To(pg.doRowQuery(
@@ -278,7 +351,7 @@ func (pg *Database) GetConnectionForJob(id, tenantID string) (*model.Connection,
 func (pg *Database) GetJobOutput(id, tenantID string, protocolType graph.ProtocolType) (output *model.JobOutput, err error) {
 	defer err2.Handle(&err)
 	switch protocolType {
-	case graph.ProtocolTypeConnection:
+	case graph.ProtocolTypeConnection, graph.ProtocolTypeTrustPing:
 		connection := try.To1(pg.getConnectionForObject("job", "protocol_connection_id", id, tenantID))
 		return &model.JobOutput{Connection: connection}, nil
 	case graph.ProtocolTypeCredential:
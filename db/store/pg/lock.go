@@ -0,0 +1,121 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// lockWatchInterval is the interval for checking the connection holding the lock
+const lockWatchInterval = 2 * time.Second
+
+// Session level advisory locks are held by a dedicated connection.
+// The lock is released when the connection is closed e.g. when the instance dies.
+// The connection is pinged in the background so that the holder learns about
+// the lost session well before the next leadership check.
+type advisoryLock struct {
+	conn *sql.Conn
+	key  int64
+
+	lost     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// TryLock acquires the advisory lock without waiting, returns nil if some other session holds it.
+// The holder is recorded as the application name of the session.
+func (pg *Database) TryLock(ctx context.Context, key int64, holder string) (lock store.Lock, err error) {
+	defer err2.Handle(&err, "TryLock")
+
+	conn := try.To1(pg.db.Conn(ctx))
+	defer func() {
+		if lock == nil {
+			conn.Close()
+		}
+	}()
+
+	try.To1(conn.ExecContext(ctx, "SELECT set_config('application_name', $1, false)", holder))
+
+	var acquired bool
+	try.To(conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired))
+	if !acquired {
+		return nil, nil
+	}
+	l := &advisoryLock{conn: conn, key: key, lost: make(chan struct{}), stop: make(chan struct{})}
+	go l.watch()
+	return l, nil
+}
+
+// GetLockHolder returns the holder of the advisory lock or empty string if the lock is free
+func (pg *Database) GetLockHolder(ctx context.Context, key int64) (holder string, err error) {
+	defer err2.Handle(&err, "GetLockHolder")
+
+	// bigint keys are split to two 32-bit fields in pg_locks
+	const sqlLockHolder = "SELECT a.application_name FROM pg_locks l" +
+		" JOIN pg_stat_activity a ON a.pid = l.pid" +
+		" WHERE l.locktype = 'advisory' AND l.granted AND l.objsubid = 1" +
+		" AND l.classid::bigint = $1 AND l.objid::bigint = $2"
+
+	err = pg.db.QueryRowContext(ctx, sqlLockHolder, key>>32, key&0xffffffff).Scan(&holder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	try.To(err)
+	return holder, nil
+}
+
+func (l *advisoryLock) Check(ctx context.Context) (err error) {
+	defer err2.Handle(&err, "Check lock")
+
+	var held bool
+	try.To(l.conn.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM pg_locks WHERE locktype = 'advisory' AND pid = pg_backend_pid()"+
+			" AND objsubid = 1 AND classid::bigint = $1 AND objid::bigint = $2)",
+		l.key>>32, l.key&0xffffffff,
+	).Scan(&held))
+	if !held {
+		return store.NewError(store.ErrCodeNotFound, "lock %d is not held", l.key)
+	}
+	return nil
+}
+
+func (l *advisoryLock) watch() {
+	ticker := time.NewTicker(lockWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), lockWatchInterval)
+		err := l.conn.PingContext(ctx)
+		cancel()
+		if err != nil {
+			glog.Warningf("Lost connection holding lock %d: %s", l.key, err.Error())
+			close(l.lost)
+			return
+		}
+	}
+}
+
+func (l *advisoryLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+func (l *advisoryLock) Release() (err error) {
+	defer err2.Handle(&err, "Release lock")
+	defer l.conn.Close()
+
+	l.stopOnce.Do(func() { close(l.stop) })
+
+	try.To1(l.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key))
+	return nil
+}
@@ -0,0 +1,91 @@
+package pg
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// ClaimNotification claims the notification state for processing until leaseUntil.
+// Returns false if the state is already processed or claimed by someone else.
+// A claim whose lease has passed without the state being processed can be claimed again.
+func (pg *Database) ClaimNotification(tenantID, protocolID, state string, leaseUntil time.Time) (claimed bool, err error) {
+	defer err2.Handle(&err, "ClaimNotification")
+
+	const sqlNotificationClaim = "INSERT INTO processed_notification (tenant_id, protocol_id, state, claimed_until)" +
+		" VALUES ($1, $2, $3, $4) ON CONFLICT (tenant_id, protocol_id, state) DO UPDATE SET claimed_until = $4" +
+		" WHERE processed_notification.processed = timestamp '0001-01-01'" +
+		" AND processed_notification.claimed_until < (now() at time zone 'UTC')" +
+		" RETURNING protocol_id"
+
+	err = pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&protocolID)
+		},
+		sqlNotificationClaim,
+		tenantID,
+		protocolID,
+		state,
+		leaseUntil,
+	)
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		// conflict, state is already processed or claimed
+		return false, nil
+	}
+	try.To(err)
+
+	return true, nil
+}
+
+// MarkNotificationProcessed records the claimed notification state as processed
+func (pg *Database) MarkNotificationProcessed(tenantID, protocolID, state string) (err error) {
+	defer err2.Handle(&err, "MarkNotificationProcessed")
+
+	const sqlNotificationProcessed = "UPDATE processed_notification SET processed = (now() at time zone 'UTC')" +
+		" WHERE tenant_id = $1 AND protocol_id = $2 AND state = $3 RETURNING protocol_id"
+
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&protocolID)
+		},
+		sqlNotificationProcessed,
+		tenantID,
+		protocolID,
+		state,
+	))
+	return
+}
+
+// ReleaseNotification removes the claim so that the notification can be retried
+func (pg *Database) ReleaseNotification(tenantID, protocolID, state string) (err error) {
+	defer err2.Handle(&err, "ReleaseNotification")
+
+	const sqlNotificationDelete = "DELETE FROM processed_notification" +
+		" WHERE tenant_id = $1 AND protocol_id = $2 AND state = $3" +
+		" AND processed = timestamp '0001-01-01' RETURNING protocol_id"
+
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&protocolID)
+		},
+		sqlNotificationDelete,
+		tenantID,
+		protocolID,
+		state,
+	))
+	return
+}
+
+// DeleteProcessedNotifications removes the states processed before the given time
+func (pg *Database) DeleteProcessedNotifications(before time.Time) (count int64, err error) {
+	defer err2.Handle(&err, "DeleteProcessedNotifications")
+
+	const sqlNotificationCleanup = "DELETE FROM processed_notification" +
+		" WHERE processed > timestamp '0001-01-01' AND processed < $1"
+
+	result := try.To1(pg.db.Exec(sqlNotificationCleanup, before))
+	return result.RowsAffected()
+}
@@ -0,0 +1,126 @@
+package pg
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+var (
+	outboxFields = []string{"tenant_id", "connection_id", "protocol_type", "payload", "attempts", "next_attempt",
+		"last_error", "delivered", "failed"}
+
+	sqlBaseOutboxFields = sqlFields("", outboxFields)
+	sqlOutboxSelect     = "SELECT id, " + sqlBaseOutboxFields + ", created, cursor FROM"
+)
+
+func readRowToOutboxItem(o *model.OutboxItem) func(*sql.Rows) error {
+	return func(rows *sql.Rows) error {
+		return rows.Scan(
+			&o.ID,
+			&o.TenantID,
+			&o.ConnectionID,
+			&o.ProtocolType,
+			&o.Payload,
+			&o.Attempts,
+			&o.NextAttempt,
+			&o.LastError,
+			&o.Delivered,
+			&o.Failed,
+			&o.Created,
+			&o.Cursor,
+		)
+	}
+}
+
+func (pg *Database) AddOutboxItem(arg *model.OutboxItem) (o *model.OutboxItem, err error) {
+	defer err2.Handle(&err, "AddOutboxItem")
+
+	var (
+		sqlOutboxInsert = "INSERT INTO outbox " + "(" + sqlBaseOutboxFields + ") " +
+			"VALUES (" + sqlArguments(outboxFields) + ") RETURNING " + sqlInsertFields
+	)
+
+	o = &model.OutboxItem{}
+	*o = *arg
+	if o.NextAttempt.IsZero() {
+		o.NextAttempt = time.Now().UTC()
+	}
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&o.ID, &o.Created, &o.Cursor)
+		},
+		sqlOutboxInsert,
+		o.TenantID,
+		o.ConnectionID,
+		o.ProtocolType,
+		o.Payload,
+		o.Attempts,
+		o.NextAttempt,
+		o.LastError,
+		o.Delivered,
+		o.Failed,
+	))
+
+	return o, err
+}
+
+func (pg *Database) UpdateOutboxItem(arg *model.OutboxItem) (o *model.OutboxItem, err error) {
+	defer err2.Handle(&err, "UpdateOutboxItem")
+
+	sqlOutboxUpdate := "UPDATE outbox SET attempts=$1, next_attempt=$2, last_error=$3, delivered=$4, failed=$5" +
+		" WHERE id = $6 AND tenant_id = $7" +
+		" RETURNING id," + sqlBaseOutboxFields + ", created, cursor"
+
+	o = &model.OutboxItem{}
+	try.To(pg.doRowQuery(
+		readRowToOutboxItem(o),
+		sqlOutboxUpdate,
+		arg.Attempts,
+		arg.NextAttempt,
+		arg.LastError,
+		arg.Delivered,
+		arg.Failed,
+		arg.ID,
+		arg.TenantID,
+	))
+	return o, err
+}
+
+func (pg *Database) GetOutboxItem(id, tenantID string) (o *model.OutboxItem, err error) {
+	defer err2.Handle(&err, "GetOutboxItem")
+
+	sqlOutboxSelectByID := sqlOutboxSelect + " outbox WHERE id=$1 AND tenant_id=$2"
+
+	o = &model.OutboxItem{}
+	try.To(pg.doRowQuery(
+		readRowToOutboxItem(o),
+		sqlOutboxSelectByID,
+		id,
+		tenantID,
+	))
+	return o, err
+}
+
+// ClaimDueOutboxItem leases the oldest due item to the caller by moving its next attempt to leaseUntil.
+// The row lock is skipped by concurrent claimers, so each item is claimed by one worker at a time.
+func (pg *Database) ClaimDueOutboxItem(before, leaseUntil time.Time) (o *model.OutboxItem, err error) {
+	defer err2.Handle(&err, "ClaimDueOutboxItem")
+
+	const sqlOutboxClaimDue = "UPDATE outbox SET next_attempt=$2 WHERE id = (" +
+		"SELECT id FROM outbox" +
+		" WHERE delivered = timestamp '0001-01-01' AND failed = timestamp '0001-01-01' AND next_attempt <= $1" +
+		" ORDER BY next_attempt ASC LIMIT 1 FOR UPDATE SKIP LOCKED)"
+
+	o = &model.OutboxItem{}
+	try.To(pg.doRowQuery(
+		readRowToOutboxItem(o),
+		sqlOutboxClaimDue+" RETURNING id,"+sqlBaseOutboxFields+", created, cursor",
+		before,
+		leaseUntil,
+	))
+	return o, err
+}
@@ -84,7 +84,8 @@ func getBatchQuery(
 }
 
 type Database struct {
-	db *sql.DB
+	db       *sql.DB
+	connInfo string
 }
 
 func createNewDB(config *utils.Configuration) {
@@ -159,7 +160,10 @@ func InitDB(config *utils.Configuration, reset, createDB bool) store.DB {
 
 	glog.Infof("successfully connected to postgres %s:%d\n", config.DBHost, config.DBPort)
 
-	return &Database{db: sqlDB}
+	return &Database{
+		db:       sqlDB,
+		connInfo: psqlInfo,
+	}
 }
 
 func (pg *Database) Close() {
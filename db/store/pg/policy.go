@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"database/sql"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"github.com/lib/pq"
+)
+
+var (
+	policyFields = []string{"tenant_id", "protocol_type", "action", "connection_id", "their_label", "their_did", "cred_def_id", "attributes"}
+
+	sqlBasePolicyFields = sqlFields("", policyFields)
+	sqlPolicySelect     = "SELECT id, " + sqlBasePolicyFields + ", created, cursor FROM"
+)
+
+func readRowToPolicy(p *model.Policy) func(*sql.Rows) error {
+	return func(rows *sql.Rows) error {
+		return rows.Scan(
+			&p.ID,
+			&p.TenantID,
+			&p.ProtocolType,
+			&p.Action,
+			&p.ConnectionID,
+			&p.TheirLabel,
+			&p.TheirDid,
+			&p.CredDefID,
+			pq.Array(&p.Attributes),
+			&p.Created,
+			&p.Cursor,
+		)
+	}
+}
+
+func (pg *Database) AddPolicy(arg *model.Policy) (p *model.Policy, err error) {
+	defer err2.Handle(&err, "AddPolicy")
+
+	var (
+		sqlPolicyInsert = "INSERT INTO tenant_policy " + "(" + sqlBasePolicyFields + ") " +
+			"VALUES (" + sqlArguments(policyFields) + ") RETURNING " + sqlInsertFields
+	)
+
+	p = &model.Policy{}
+	*p = *arg
+	if p.Attributes == nil {
+		p.Attributes = make([]string, 0)
+	}
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&p.ID, &p.Created, &p.Cursor)
+		},
+		sqlPolicyInsert,
+		p.TenantID,
+		p.ProtocolType,
+		p.Action,
+		p.ConnectionID,
+		p.TheirLabel,
+		p.TheirDid,
+		p.CredDefID,
+		pq.Array(p.Attributes),
+	))
+
+	return p, err
+}
+
+func (pg *Database) UpdatePolicy(arg *model.Policy) (p *model.Policy, err error) {
+	defer err2.Handle(&err, "UpdatePolicy")
+
+	sqlPolicyUpdate := "UPDATE tenant_policy SET protocol_type=$1, action=$2, connection_id=$3, their_label=$4," +
+		" their_did=$5, cred_def_id=$6, attributes=$7 WHERE id = $8 AND tenant_id = $9" +
+		" RETURNING id," + sqlBasePolicyFields + ", created, cursor"
+
+	attributes := arg.Attributes
+	if attributes == nil {
+		attributes = make([]string, 0)
+	}
+	p = &model.Policy{}
+	try.To(pg.doRowQuery(
+		readRowToPolicy(p),
+		sqlPolicyUpdate,
+		arg.ProtocolType,
+		arg.Action,
+		arg.ConnectionID,
+		arg.TheirLabel,
+		arg.TheirDid,
+		arg.CredDefID,
+		pq.Array(attributes),
+		arg.ID,
+		arg.TenantID,
+	))
+	return p, err
+}
+
+func (pg *Database) GetPolicies(tenantID string) (p []*model.Policy, err error) {
+	defer err2.Handle(&err, "GetPolicies")
+
+	sqlPolicySelectByTenant := sqlPolicySelect + " tenant_policy WHERE tenant_id=$1 ORDER BY cursor ASC"
+
+	p = make([]*model.Policy, 0)
+	if err = pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		policy := &model.Policy{}
+		err = readRowToPolicy(policy)(rows)
+		p = append(p, policy)
+		return err
+	}, sqlPolicySelectByTenant, tenantID); err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		// no policies is not an error
+		err = nil
+	}
+	try.To(err)
+
+	return p, nil
+}
+
+func (pg *Database) RemovePolicy(id, tenantID string) (err error) {
+	defer err2.Handle(&err, "RemovePolicy")
+
+	const sqlPolicyDelete = "DELETE FROM tenant_policy WHERE id = $1 AND tenant_id = $2 RETURNING id"
+
+	try.To(pg.doRowQuery(
+		func(rows *sql.Rows) error {
+			return rows.Scan(&id)
+		},
+		sqlPolicyDelete,
+		id,
+		tenantID,
+	))
+	return
+}
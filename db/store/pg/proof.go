@@ -3,10 +3,12 @@ package pg
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sort"
 
 	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/findy-network/findy-agent-vault/utils"
@@ -15,10 +17,32 @@ import (
 )
 
 func constructProofAttributeInsert(count int) string {
-	const sqlProofAttributeInsert = "INSERT INTO proof_attribute (proof_id, name, value, cred_def_id, index) VALUES "
+	const sqlProofAttributeInsert = "INSERT INTO proof_attribute (proof_id, name, value, cred_def_id, restrictions, index) VALUES "
 
 	result := sqlProofAttributeInsert
-	paramCount := 5
+	paramCount := 6
+	for i := 0; i < count; i++ {
+		if i >= 1 {
+			result += ","
+		}
+		nbr := i*paramCount + 1
+		params := ""
+		for j := 0; j < paramCount; j++ {
+			if j >= 1 {
+				params += ","
+			}
+			params = fmt.Sprintf("%s$%d", params, (nbr + j))
+		}
+		result += fmt.Sprintf("(%s)", params)
+	}
+	return result + " RETURNING id"
+}
+
+func constructProofPredicateInsert(count int) string {
+	const sqlProofPredicateInsert = "INSERT INTO proof_predicate (proof_id, name, operator, value, cred_def_id, index) VALUES "
+
+	result := sqlProofPredicateInsert
+	paramCount := 6
 	for i := 0; i < count; i++ {
 		if i >= 1 {
 			result += ","
@@ -42,7 +66,7 @@ var (
 
 	sqlProofBaseFields = sqlFields("", proofFields)
 	sqlProofSelect     = "SELECT proof.id, " + sqlProofBaseFields +
-		", " + sqlFields("", proofExtraFields) + ", proof_attribute.id, name, value, cred_def_id FROM"
+		", " + sqlFields("", proofExtraFields) + ", proof_attribute.id, name, value, cred_def_id, restrictions FROM"
 )
 
 const (
@@ -54,7 +78,7 @@ func (pg *Database) getProofForObject(objectName, columnName, objectID, tenantID
 
 	sqlProofJoinSelect := "SELECT proof.id, " + sqlFields("proof", proofFields) +
 		", " + sqlFields("proof", proofExtraFields) +
-		", proof_attribute.id, proof_attribute.name, proof_attribute.value, proof_attribute.cred_def_id FROM"
+		", proof_attribute.id, proof_attribute.name, proof_attribute.value, proof_attribute.cred_def_id, proof_attribute.restrictions FROM"
 	sqlProofSelectByObjectID := sqlProofJoinSelect + " proof " + sqlProofJoin +
 		" INNER JOIN " + objectName + " ON " + objectName +
 		"." + columnName + "=proof.id WHERE " + objectName + ".id = $1 AND proof.tenant_id = $2"
@@ -66,17 +90,88 @@ func (pg *Database) getProofForObject(objectName, columnName, objectID, tenantID
 		return
 	}, sqlProofSelectByObjectID, objectID, tenantID))
 
+	try.To(pg.getProofPredicates([]*model.Proof{c}))
+
 	return
 }
 
+func (pg *Database) addProofPredicates(id string, predicates []*graph.ProofPredicate) (p []*graph.ProofPredicate, err error) {
+	defer err2.Handle(&err, "addProofPredicates")
+
+	query := constructProofPredicateInsert(len(predicates))
+	args := make([]interface{}, 0)
+	for index, p := range predicates {
+		args = append(args, []interface{}{id, p.Name, p.Operator, p.Value, p.CredDefID, index}...)
+	}
+
+	index := 0
+	try.To(pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		defer err2.Handle(&err)
+		try.To(rows.Scan(&predicates[index].ID))
+		index++
+		return
+	}, query, args...))
+
+	return predicates, nil
+}
+
+// getProofPredicates fills predicates for given proofs.
+// Predicates are fetched separately to avoid multiplying the attribute join rows.
+func (pg *Database) getProofPredicates(proofs []*model.Proof) (err error) {
+	defer err2.Handle(&err, "getProofPredicates")
+
+	const sqlProofPredicateSelect = "SELECT proof_id, id, name, operator, value, cred_def_id FROM proof_predicate" +
+		" WHERE proof_id IN (%s) ORDER BY proof_id, index"
+
+	proofsByID := make(map[string]*model.Proof)
+	ids := ""
+	args := make([]interface{}, 0)
+	for _, proof := range proofs {
+		proof.Predicates = make([]*graph.ProofPredicate, 0)
+		if proof.ID == "" {
+			continue
+		}
+		proofsByID[proof.ID] = proof
+		args = append(args, proof.ID)
+		if ids != "" {
+			ids += ","
+		}
+		ids += fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	if err = pg.doRowsQuery(func(rows *sql.Rows) (err error) {
+		defer err2.Handle(&err)
+		var proofID string
+		p := &graph.ProofPredicate{}
+		try.To(rows.Scan(&proofID, &p.ID, &p.Name, &p.Operator, &p.Value, &p.CredDefID))
+		if proof, ok := proofsByID[proofID]; ok {
+			proof.Predicates = append(proof.Predicates, p)
+		}
+		return
+	}, fmt.Sprintf(sqlProofPredicateSelect, ids), args...); err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		// proofs without predicates are valid
+		err = nil
+	}
+
+	return err
+}
+
 func (pg *Database) addProofAttributes(id string, attributes []*graph.ProofAttribute) (a []*graph.ProofAttribute, err error) {
 	defer err2.Handle(&err, "addProofAttributes")
 
 	query := constructProofAttributeInsert(len(attributes))
 	args := make([]interface{}, 0)
 	for index, a := range attributes {
+		if a.Restrictions == nil {
+			a.Restrictions = make([]*graph.ProofRestriction, 0)
+		}
+		restrictions := try.To1(json.Marshal(a.Restrictions))
 		// TODO: save values when received
-		args = append(args, []interface{}{id, a.Name, "", a.CredDefID, index}...)
+		args = append(args, []interface{}{id, a.Name, "", a.CredDefID, restrictions, index}...)
 	}
 
 	index := 0
@@ -122,6 +217,11 @@ func (pg *Database) AddProof(p *model.Proof) (proof *model.Proof, err error) {
 	attributes := try.To1(pg.addProofAttributes(proof.ID, proof.Attributes))
 
 	proof.Attributes = attributes
+
+	proof.Predicates = make([]*graph.ProofPredicate, 0)
+	if len(p.Predicates) > 0 {
+		proof.Predicates = try.To1(pg.addProofPredicates(proof.ID, p.Predicates))
+	}
 	return proof, err
 }
 
@@ -177,6 +277,7 @@ func readRowToProof(rows *sql.Rows, previous *model.Proof) (*model.Proof, error)
 
 	value := &graph.ProofValue{}
 
+	var restrictions []byte
 	err := rows.Scan(
 		&n.ID,
 		&n.TenantID,
@@ -195,7 +296,16 @@ func readRowToProof(rows *sql.Rows, previous *model.Proof) (*model.Proof, error)
 		&a.Name,
 		&value.Value,
 		&a.CredDefID,
+		&restrictions,
 	)
+	if err != nil {
+		return n, err
+	}
+
+	a.Restrictions = make([]*graph.ProofRestriction, 0)
+	if err = json.Unmarshal(restrictions, &a.Restrictions); err != nil {
+		return n, err
+	}
 
 	n.Attributes = make([]*graph.ProofAttribute, 0)
 	if previous.ID == n.ID {
@@ -230,6 +340,8 @@ func (pg *Database) GetProof(id, tenantID string) (p *model.Proof, err error) {
 		return
 	}, sqlProofSelectByID, id, tenantID))
 
+	try.To(pg.getProofPredicates([]*model.Proof{p}))
+
 	return
 }
 
@@ -278,6 +390,8 @@ func (pg *Database) getProofsForQuery(
 		}
 	}
 
+	try.To(pg.getProofPredicates(p.Proofs))
+
 	if batch.After > 0 {
 		p.HasPreviousPage = true
 	}
@@ -0,0 +1,104 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"github.com/lib/pq"
+)
+
+const (
+	eventChannel = "vault_event"
+
+	minListenerReconnect = time.Second
+	maxListenerReconnect = time.Minute
+)
+
+var eventListenerReconnects = expvar.NewInt("event_listener_reconnects")
+
+// PublishEvent notifies the event listeners of all vault replicas
+func (pg *Database) PublishEvent(ctx context.Context, event *model.PublishedEvent) (err error) {
+	defer err2.Handle(&err, "PublishEvent")
+
+	payload := try.To1(json.Marshal(event))
+	try.To1(pg.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", eventChannel, string(payload)))
+	return nil
+}
+
+// ListenEvents delivers the events published by the vault replicas until the context is done.
+// Lost connection is reconnected automatically, the events published while
+// the listener was disconnected are not delivered.
+func (pg *Database) ListenEvents(ctx context.Context, pingInterval time.Duration) (events <-chan *model.PublishedEvent, err error) {
+	defer err2.Handle(&err, "ListenEvents")
+
+	listener := pq.NewListener(pg.connInfo, minListenerReconnect, maxListenerReconnect,
+		func(event pq.ListenerEventType, listenErr error) {
+			switch event {
+			case pq.ListenerEventDisconnected:
+				glog.Warningf("Event listener disconnected: %v", listenErr)
+			case pq.ListenerEventReconnected:
+				glog.Warningln("Event listener reconnected, events published meanwhile were missed")
+				eventListenerReconnects.Add(1)
+			case pq.ListenerEventConnectionAttemptFailed:
+				glog.Warningf("Event listener connection attempt failed: %v", listenErr)
+			case pq.ListenerEventConnected:
+			}
+		})
+	defer func() {
+		if err != nil {
+			listener.Close()
+		}
+	}()
+	try.To(listener.Listen(eventChannel))
+
+	ch := make(chan *model.PublishedEvent)
+	go pg.dispatchEvents(ctx, listener, ch, pingInterval)
+	return ch, nil
+}
+
+func (pg *Database) dispatchEvents(
+	ctx context.Context,
+	listener *pq.Listener,
+	ch chan<- *model.PublishedEvent,
+	pingInterval time.Duration,
+) {
+	defer close(ch)
+	defer listener.Close()
+
+	// ping detects broken connections when there are no notifications
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					glog.Warningf("Event listener ping failed: %s", err.Error())
+				}
+			}()
+		case notification := <-listener.Notify:
+			// nil is sent after reconnect
+			if notification == nil {
+				continue
+			}
+			event := &model.PublishedEvent{}
+			if err := json.Unmarshal([]byte(notification.Extra), event); err != nil {
+				glog.Errorf("Invalid event notification %s: %s", notification.Extra, err.Error())
+				continue
+			}
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
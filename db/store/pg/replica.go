@@ -0,0 +1,60 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// UpdateReplica registers the vault replica or refreshes its heartbeat and the ring it has applied
+func (pg *Database) UpdateReplica(ctx context.Context, id, ring string) (err error) {
+	defer err2.Handle(&err, "UpdateReplica")
+
+	const sqlReplicaUpsert = "INSERT INTO replica (id, ring) VALUES ($1, $2)" +
+		" ON CONFLICT (id) DO UPDATE SET heartbeat = (now() at time zone 'UTC'), ring = $2"
+
+	try.To1(pg.db.ExecContext(ctx, sqlReplicaUpsert, id, ring))
+	return nil
+}
+
+// GetReplicas returns the replicas that have sent heartbeat within the timeout.
+// Database time is used so that the clocks of the replicas do not need to be in sync.
+func (pg *Database) GetReplicas(ctx context.Context, timeout time.Duration) (replicas []*model.Replica, err error) {
+	defer err2.Handle(&err, "GetReplicas")
+
+	const sqlReplicaSelect = "SELECT id, ring FROM replica" +
+		" WHERE heartbeat > (now() at time zone 'UTC') - $1::float8 * interval '1 millisecond' ORDER BY id"
+
+	rows := try.To1(pg.db.QueryContext(ctx, sqlReplicaSelect, timeout.Milliseconds()))
+	defer rows.Close()
+
+	replicas = make([]*model.Replica, 0)
+	for rows.Next() {
+		replica := &model.Replica{}
+		try.To(rows.Scan(&replica.ID, &replica.Ring))
+		replicas = append(replicas, replica)
+	}
+	try.To(rows.Err())
+
+	return replicas, nil
+}
+
+// RemoveReplica unregisters the replica so that others do not need to wait for the heartbeat timeout
+func (pg *Database) RemoveReplica(ctx context.Context, id string) (err error) {
+	defer err2.Handle(&err, "RemoveReplica")
+
+	const sqlReplicaDelete = "DELETE FROM replica WHERE id = $1 RETURNING id"
+
+	err = pg.db.QueryRowContext(ctx, sqlReplicaDelete, id).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.NewError(store.ErrCodeNotFound, "replica %s not found", id)
+	}
+	try.To(err)
+	return nil
+}
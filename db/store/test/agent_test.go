@@ -83,6 +83,40 @@ func agentValidator(t *testing.T, testAgent *model.Agent) func(a *model.Agent) {
 	}
 }
 
+func TestGetAccessedAgents(t *testing.T) {
+	testAgent := &model.Agent{}
+	testAgent.AgentID = "accessedAgentID"
+	testAgent.Label = "accessedAgentLabel"
+	testAgent.RawJWT = "jwt-token"
+
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("get accessed agents "+s.name, func(t *testing.T) {
+			assert.PushTester(t)
+			defer assert.PopTester()
+
+			agent, err := s.db.AddAgent(testAgent)
+			assert.NoError(err, "Failed to add agent %v", err)
+
+			agents, err := s.db.GetAccessedAgents(agent.LastAccessed.Add(-time.Second))
+			assert.NoError(err, "Failed to get accessed agents %v", err)
+			found := false
+			for _, a := range agents {
+				if a.ID == agent.ID {
+					found = true
+				}
+			}
+			assert.That(found, "Did not receive accessed agent")
+
+			agents, err = s.db.GetAccessedAgents(agent.LastAccessed)
+			assert.NoError(err, "Failed to get accessed agents %v", err)
+			for _, a := range agents {
+				assert.That(a.ID != agent.ID, "Received agent accessed before given time")
+			}
+		})
+	}
+}
+
 func TestAddAgent(t *testing.T) {
 	for index := range DBs {
 		store := DBs[index]
@@ -41,6 +41,11 @@ func validateConnection(t *testing.T, exp, got *model.Connection) {
 	validateCreatedTS(t, got.Cursor, &got.Created)
 	validateTimestap(t, &exp.Approved, &got.Approved, "Approved")
 	validateTimestap(t, &exp.Archived, &got.Archived, "Archived")
+	validateTimestap(t, &exp.LastSeen, &got.LastSeen, "LastSeen")
+	validateTimestap(t, &exp.LastPing, &got.LastPing, "LastPing")
+	if got.LastPingResult != exp.LastPingResult {
+		t.Errorf("Connection last ping result mismatch expected %v got %v", exp.LastPingResult, got.LastPingResult)
+	}
 }
 
 func TestAddConnection(t *testing.T) {
@@ -67,6 +72,32 @@ func TestAddConnection(t *testing.T) {
 	}
 }
 
+func TestUpdateConnection(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("update connection "+s.name, func(t *testing.T) {
+			s.updateTestConnection()
+			// Add data
+			c, err := s.db.AddConnection(s.testConnection)
+			if err != nil {
+				t.Errorf("Failed to add connection %s", err.Error())
+			}
+
+			// Update data
+			now := utils.CurrentTime()
+			c.LastSeen = now
+			c.LastPing = now
+			c.LastPingResult = true
+			got, err := s.db.UpdateConnection(c)
+			if err != nil {
+				t.Errorf("Failed to update connection %s", err.Error())
+			} else {
+				validateConnection(t, c, got)
+			}
+		})
+	}
+}
+
 func TestAddConnectionSameIDDifferentTenant(t *testing.T) {
 	for index := range DBs {
 		s := DBs[index]
@@ -418,3 +418,50 @@ func TestSearchCredentialsWithApostrophe(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchCredentialsWithRestrictions(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("search credentials with restrictions "+s.name, func(t *testing.T) {
+			now := utils.CurrentTime()
+			credential := s.newTestCredential(testCredential)
+
+			// Add data
+			credential.SchemaID = "restrictionIssuer:2:restrictionSchema:1.0"
+			credential.CredDefID = "restrictionIssuer:3:CL:1:restriction"
+			c, err := s.db.AddCredential(credential)
+			assert.That(err == nil)
+
+			c.Issued = now
+			c, err = s.db.UpdateCredential(c)
+			assert.That(err == nil)
+
+			attribute := &graph.ProofAttribute{
+				ID:   "restricted",
+				Name: credential.Attributes[0].Name,
+				Restrictions: []*graph.ProofRestriction{
+					{SchemaName: "otherSchema"},
+					{IssuerDid: "restrictionIssuer", SchemaName: "restrictionSchema"},
+				},
+			}
+			res, err := s.db.SearchCredentials(s.testTenantID, []*graph.ProofAttribute{attribute})
+			if err != nil {
+				t.Errorf("Encountered error when searching for creds %s", err)
+			}
+			if len(res[0].Credentials) != 1 || res[0].Credentials[0].CredentialID != c.ID {
+				t.Errorf("Expected credential was not found")
+			}
+
+			attribute.Restrictions = []*graph.ProofRestriction{
+				{IssuerDid: "restrictionIssuer", SchemaName: "otherSchema"},
+			}
+			res, err = s.db.SearchCredentials(s.testTenantID, []*graph.ProofAttribute{attribute})
+			if err != nil {
+				t.Errorf("Encountered error when searching for creds %s", err)
+			}
+			if len(res[0].Credentials) != 0 {
+				t.Errorf("Found credential not matching restrictions")
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 )
 
 const testAgentLabel = "testAgent"
+const testJobTimeout = 60
 
 type testableDB struct {
 	db               store.DB
@@ -100,8 +101,7 @@ var (
 		},
 	}
 	testMessage *model.Message = &model.Message{Message: "msg content",
-		SentByMe:  false,
-		Delivered: false,
+		SentByMe: false,
 	}
 
 	testEvent *model.Event = &model.Event{
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestDeadLetter(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("dead letter "+s.name, func(t *testing.T) {
+			letter, err := s.db.AddDeadLetter(&model.DeadLetter{
+				Base:           model.Base{TenantID: s.testTenantID},
+				ProtocolID:     "TestDeadLetter",
+				ProtocolType:   graph.ProtocolTypeProof,
+				ConnectionID:   s.testConnectionID,
+				Notification:   []byte(`{"typeID":"STATUS_UPDATE"}`),
+				ProtocolStatus: []byte(`{}`),
+				LastError:      "failure",
+			})
+			if err != nil {
+				t.Errorf("Failed to add dead letter %s", err.Error())
+				return
+			}
+			if letter.ID == "" || letter.NextAttempt.IsZero() {
+				t.Errorf("Invalid dead letter %+v", letter)
+			}
+
+			leaseUntil := time.Now().UTC().Add(time.Minute)
+			if claimed := claimDeadLetter(t, s.db, letter.ID, 1, leaseUntil); claimed == nil {
+				t.Errorf("Due dead letter %s not claimed", letter.ID)
+			}
+			// claimed letter is not available until the lease passes
+			if _, err = s.db.ClaimDeadLetter(letter.ID, leaseUntil); err == nil {
+				t.Errorf("Dead letter %s claimed twice", letter.ID)
+			}
+
+			letter.Attempts = 1
+			letter.LastError = "another failure"
+			updated, err := s.db.UpdateDeadLetter(letter)
+			if err != nil {
+				t.Errorf("Failed to update dead letter %s", err.Error())
+				return
+			}
+			if updated.Attempts != 1 || updated.LastError != "another failure" || updated.ProtocolID != letter.ProtocolID {
+				t.Errorf("Dead letter update mismatch %+v", updated)
+			}
+
+			// attempts exhausted
+			if claimed := claimDeadLetter(t, s.db, letter.ID, 1, leaseUntil); claimed != nil {
+				t.Errorf("Dead letter %s should not be due", letter.ID)
+			}
+
+			// update releases the claim
+			if _, err = s.db.ClaimDeadLetter(letter.ID, leaseUntil); err != nil {
+				t.Errorf("Failed to claim dead letter %s", err.Error())
+			}
+
+			letters, err := s.db.GetDeadLetters(&s.testTenantID)
+			if err != nil {
+				t.Errorf("Error fetching dead letters %s", err.Error())
+			} else if len(letters) == 0 {
+				t.Errorf("Dead letter %s not listed", letter.ID)
+			}
+
+			if err = s.db.RemoveDeadLetter(letter.ID, s.testTenantID); err != nil {
+				t.Errorf("Failed to remove dead letter %s", err.Error())
+			}
+			if _, err = s.db.GetDeadLetter(letter.ID); err == nil {
+				t.Errorf("Removed dead letter %s still exists", letter.ID)
+			}
+		})
+	}
+}
+
+func claimDeadLetter(t *testing.T, db store.DB, id string, maxAttempts int, leaseUntil time.Time) (claimed *model.DeadLetter) {
+	released := make([]*model.DeadLetter, 0)
+	defer func() {
+		for _, letter := range released {
+			if _, err := db.UpdateDeadLetter(letter); err != nil {
+				t.Errorf("Failed to release dead letter %s", err.Error())
+			}
+		}
+	}()
+
+	for {
+		letter, err := db.ClaimDueDeadLetter(time.Now().UTC(), leaseUntil, maxAttempts)
+		if err != nil {
+			if store.ErrorCode(err) != store.ErrCodeNotFound {
+				t.Errorf("Error claiming due dead letter %s", err.Error())
+			}
+			return nil
+		}
+		if letter.ID == id {
+			return letter
+		}
+		released = append(released, letter)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/findy-network/findy-agent-vault/db/fake"
 	"github.com/findy-network/findy-agent-vault/db/model"
@@ -29,7 +30,13 @@ func validateEvent(t *testing.T, exp, got *model.Event) {
 	if got.Read != exp.Read {
 		t.Errorf("Event Read mismatch expected %v got %v", exp.Read, got.Read)
 	}
-	validateCreatedTS(t, got.Cursor, &got.Created)
+	if time.Since(got.Created) > time.Second {
+		t.Errorf("Timestamp not in threshold %v", got.Created)
+	}
+	// event cursor is a sequence number instead of the creation timestamp
+	if got.Cursor == 0 {
+		t.Errorf("Cursor invalid %v", got.Cursor)
+	}
 }
 
 func validateEvents(t *testing.T, expCount int, exp, got *model.Events) {
@@ -40,6 +40,12 @@ func validateJob(t *testing.T, exp, got *model.Job) {
 	if got.InitiatedByUs != exp.InitiatedByUs {
 		t.Errorf("Job initiatedByUs mismatch expected %v got %v", exp.InitiatedByUs, got.InitiatedByUs)
 	}
+	if got.FailureCode != exp.FailureCode {
+		t.Errorf("Job failure code mismatch expected %s got %s", exp.FailureCode, got.FailureCode)
+	}
+	if got.FailureReason != exp.FailureReason {
+		t.Errorf("Job failure reason mismatch expected %s got %s", exp.FailureReason, got.FailureReason)
+	}
 
 	validateCreatedTS(t, got.Cursor, &got.Created)
 }
@@ -186,7 +192,9 @@ func TestUpdateJob(t *testing.T) {
 			// Update data
 			j.ProtocolConnectionID = &s.testConnectionID
 			j.Status = graph.JobStatusComplete
-			j.Result = graph.JobResultSuccess
+			j.Result = graph.JobResultFailure
+			j.FailureCode = "ERR"
+			j.FailureReason = "failure reason"
 			j.Updated = time.Now().UTC()
 			got, err := s.db.UpdateJob(j)
 			if err != nil {
@@ -449,3 +457,52 @@ func TestGetOpenProofJobs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExpiredJobs(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("get expired jobs "+s.name, func(t *testing.T) {
+			a, connections := AddAgentAndConnections(s.db, "TestGetExpiredJobs", 1)
+			connection := connections[0]
+			proofs := fake.AddProofs(s.db, a.ID, connection.ID, 1, false)
+			waitingJob := fake.AddProofJobs(s.db, a.ID, connection.ID, proofs[0].ID, 1, graph.JobStatusWaiting)[0]
+			pendingJob := fake.AddProofJobs(s.db, a.ID, connection.ID, proofs[0].ID, 1, graph.JobStatusPending)[0]
+
+			for _, job := range []*model.Job{waitingJob, pendingJob} {
+				job.Expires = time.Now().UTC().Add(testJobTimeout * time.Second)
+				updated, err := s.db.UpdateJob(job)
+				if err != nil {
+					t.Fatalf("Error updating job %s", err.Error())
+				}
+				if updated.Expires.IsZero() {
+					t.Errorf("Expiry not set for job %s", job.ID)
+				}
+			}
+
+			got, err := s.db.GetExpiredJobs(time.Now().UTC(), 100)
+			if err != nil {
+				t.Errorf("Error getting expired jobs %s", err.Error())
+			}
+			for _, job := range got {
+				if job.ID == waitingJob.ID {
+					t.Errorf("Job %s expired before timeout", job.ID)
+				}
+			}
+
+			got, err = s.db.GetExpiredJobs(time.Now().UTC().Add(2*testJobTimeout*time.Second), 1000)
+			if err != nil {
+				t.Errorf("Error getting expired jobs %s", err.Error())
+			}
+			found := false
+			for _, job := range got {
+				if job.TenantID == a.ID && job.ID != waitingJob.ID {
+					t.Errorf("Job %s in status %s should not expire", job.ID, job.Status)
+				}
+				found = found || job.ID == waitingJob.ID
+			}
+			if !found {
+				t.Errorf("Expired job %s was not found", waitingJob.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLock(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("lock "+s.name, func(t *testing.T) {
+			const (
+				key    = int64(1) << 40
+				holder = "test-holder"
+			)
+			ctx := context.Background()
+
+			lock, err := s.db.TryLock(ctx, key, holder)
+			if err != nil || lock == nil {
+				t.Fatalf("Failed to acquire lock %v", err)
+			}
+			if err = lock.Check(ctx); err != nil {
+				t.Errorf("Lock should be held %s", err.Error())
+			}
+
+			other, err := s.db.TryLock(ctx, key, "other-holder")
+			if err != nil {
+				t.Errorf("Failed to try lock %s", err.Error())
+			}
+			if other != nil {
+				t.Errorf("Lock should not be acquired twice")
+			}
+
+			current, err := s.db.GetLockHolder(ctx, key)
+			if err != nil {
+				t.Errorf("Failed to get lock holder %s", err.Error())
+			}
+			if current != holder {
+				t.Errorf("Mismatch in lock holder, expected: %s got: %s", holder, current)
+			}
+
+			if err = lock.Release(); err != nil {
+				t.Errorf("Failed to release lock %s", err.Error())
+			}
+			if current, _ = s.db.GetLockHolder(ctx, key); current != "" {
+				t.Errorf("Released lock should have no holder, got: %s", current)
+			}
+
+			lock, err = s.db.TryLock(ctx, key, "other-holder")
+			if err != nil || lock == nil {
+				t.Fatalf("Failed to acquire released lock %v", err)
+			}
+			_ = lock.Release()
+		})
+	}
+}
@@ -45,7 +45,7 @@ func validateMessage(t *testing.T, exp, got *model.Message) {
 	if got.SentByMe != exp.SentByMe {
 		t.Errorf("Message SentByMe mismatch expected %v got %v", exp.SentByMe, got.SentByMe)
 	}
-	validateBoolPtr(t, &exp.Delivered, &got.Delivered, "Delivered")
+	validateBoolPtr(t, exp.Delivered, got.Delivered, "Delivered")
 	validateCreatedTS(t, got.Cursor, &got.Created)
 	validateTimestap(t, &exp.Archived, &got.Archived, "Archived")
 }
@@ -152,7 +152,8 @@ func TestUpdateMessage(t *testing.T) {
 			}
 
 			// Update data
-			m.Delivered = true
+			delivered := true
+			m.Delivered = &delivered
 			got, err := s.db.UpdateMessage(m)
 			if err != nil {
 				t.Errorf("Failed to update message  %s", err.Error())
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProcessedNotification(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("processed notification "+s.name, func(t *testing.T) {
+			const (
+				protocolID = "TestProcessedNotification"
+				state      = "credential:issued"
+			)
+			leaseUntil := time.Now().UTC().Add(time.Minute)
+
+			claimed, err := s.db.ClaimNotification(s.testTenantID, protocolID, state, leaseUntil)
+			if err != nil {
+				t.Errorf("Failed to claim notification %s", err.Error())
+				return
+			}
+			if !claimed {
+				t.Errorf("Expected notification to be claimed")
+			}
+
+			claimed, err = s.db.ClaimNotification(s.testTenantID, protocolID, state, leaseUntil)
+			if err != nil {
+				t.Errorf("Failed to claim duplicate notification %s", err.Error())
+			}
+			if claimed {
+				t.Errorf("Expected claimed notification to be skipped")
+			}
+
+			if err = s.db.ReleaseNotification(s.testTenantID, protocolID, state); err != nil {
+				t.Errorf("Failed to release notification %s", err.Error())
+			}
+
+			// expired lease can be claimed again
+			claimed, err = s.db.ClaimNotification(s.testTenantID, protocolID, state, time.Now().UTC().Add(-time.Minute))
+			if err != nil || !claimed {
+				t.Errorf("Expected released notification to be claimed, err %v", err)
+			}
+			claimed, err = s.db.ClaimNotification(s.testTenantID, protocolID, state, leaseUntil)
+			if err != nil || !claimed {
+				t.Errorf("Expected notification with expired lease to be claimed, err %v", err)
+			}
+
+			if err = s.db.MarkNotificationProcessed(s.testTenantID, protocolID, state); err != nil {
+				t.Errorf("Failed to mark notification processed %s", err.Error())
+			}
+			claimed, err = s.db.ClaimNotification(s.testTenantID, protocolID, state, leaseUntil)
+			if err != nil {
+				t.Errorf("Failed to claim processed notification %s", err.Error())
+			}
+			if claimed {
+				t.Errorf("Expected processed notification to be skipped")
+			}
+
+			count, err := s.db.DeleteProcessedNotifications(time.Now().UTC().Add(time.Minute))
+			if err != nil {
+				t.Errorf("Failed to delete processed notifications %s", err.Error())
+			} else if count == 0 {
+				t.Errorf("Expected processed notification to be deleted")
+			}
+			claimed, err = s.db.ClaimNotification(s.testTenantID, protocolID, state, leaseUntil)
+			if err != nil || !claimed {
+				t.Errorf("Expected deleted notification to be claimed, err %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/fake"
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestOutboxItem(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("outbox item "+s.name, func(t *testing.T) {
+			item, err := s.db.AddOutboxItem(&model.OutboxItem{
+				Base:         model.Base{TenantID: s.testTenantID},
+				ConnectionID: s.testConnectionID,
+				ProtocolType: graph.ProtocolTypeBasicMessage,
+				Payload:      []byte(`{"message":"hello"}`),
+			})
+			if err != nil {
+				t.Errorf("Failed to add outbox item %s", err.Error())
+				return
+			}
+			if item.ID == "" || item.NextAttempt.IsZero() || !item.IsPending() {
+				t.Errorf("Invalid outbox item %+v", item)
+			}
+
+			leaseUntil := time.Now().UTC().Add(time.Hour)
+			claimed := claimOutboxItem(t, s.db, item.ID, leaseUntil)
+			if claimed == nil {
+				t.Errorf("Due outbox item %s not claimed", item.ID)
+			} else if claimed.NextAttempt.Before(leaseUntil.Add(-time.Second)) {
+				t.Errorf("Claimed outbox item %s not leased", item.ID)
+			}
+			if claimOutboxItem(t, s.db, item.ID, leaseUntil) != nil {
+				t.Errorf("Leased outbox item %s claimed twice", item.ID)
+			}
+
+			item.Attempts = 1
+			item.LastError = "failure"
+			item.NextAttempt = time.Now().UTC().Add(time.Hour)
+			updated, err := s.db.UpdateOutboxItem(item)
+			if err != nil {
+				t.Errorf("Failed to update outbox item %s", err.Error())
+				return
+			}
+			if updated.Attempts != 1 || updated.LastError != "failure" || string(updated.Payload) != `{"message": "hello"}` {
+				t.Errorf("Outbox item update mismatch %+v", updated)
+			}
+
+			if claimOutboxItem(t, s.db, item.ID, leaseUntil) != nil {
+				t.Errorf("Outbox item %s should not be due", item.ID)
+			}
+
+			got, err := s.db.GetOutboxItem(item.ID, s.testTenantID)
+			if err != nil {
+				t.Errorf("Error fetching outbox item %s", err.Error())
+			} else if got.Attempts != 1 {
+				t.Errorf("Outbox item attempts mismatch expected 1 got %d", got.Attempts)
+			}
+		})
+	}
+}
+
+// claimOutboxItem claims due items until the item is found, the other claimed items are released
+func claimOutboxItem(t *testing.T, db store.DB, id string, leaseUntil time.Time) (claimed *model.OutboxItem) {
+	released := make([]*model.OutboxItem, 0)
+	defer func() {
+		for _, item := range released {
+			item.NextAttempt = time.Now().UTC()
+			if _, err := db.UpdateOutboxItem(item); err != nil {
+				t.Errorf("Failed to release outbox item %s", err.Error())
+			}
+		}
+	}()
+
+	for {
+		item, err := db.ClaimDueOutboxItem(time.Now().UTC(), leaseUntil)
+		if err != nil {
+			if store.ErrorCode(err) != store.ErrCodeNotFound {
+				t.Errorf("Error claiming due outbox item %s", err.Error())
+			}
+			return nil
+		}
+		if item.ID == id {
+			return item
+		}
+		released = append(released, item)
+	}
+}
+
+func TestLinkJob(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("link job "+s.name, func(t *testing.T) {
+			a, connections := AddAgentAndConnections(s.db, "TestLinkJob", 1)
+			connection := connections[0]
+			msgs := fake.AddMessages(s.db, a.ID, connection.ID, 2)
+			jobs := fake.AddMessageJobs(s.db, a.ID, connection.ID, msgs[0].ID, 2)
+			job, protocolJob := jobs[0], jobs[1]
+
+			job.Attempts = 3
+			job.Status = graph.JobStatusWaiting
+			if _, err := s.db.UpdateJob(job); err != nil {
+				t.Errorf("Failed to update job %s", err.Error())
+				return
+			}
+			event := fake.AddEvents(s.db, a.ID, connection.ID, &protocolJob.ID, 1)[0]
+
+			linked, err := s.db.LinkJob(job.ID, protocolJob.ID, a.ID)
+			if err != nil {
+				t.Errorf("Failed to link job %s", err.Error())
+				return
+			}
+			if linked.ID != job.ID || linked.Attempts != 3 || linked.Status != protocolJob.Status {
+				t.Errorf("Linked job mismatch %+v", linked)
+			}
+
+			got, err := s.db.GetJob(protocolJob.ID, a.ID)
+			if err != nil {
+				t.Errorf("Error fetching job with protocol ID %s", err.Error())
+			} else if got.ID != job.ID || got.ProtocolID == nil || *got.ProtocolID != protocolJob.ID {
+				t.Errorf("Job %s was not found with protocol ID %s", job.ID, protocolJob.ID)
+			}
+			gotJob, err := s.db.GetJobForEvent(event.ID, a.ID)
+			if err != nil {
+				t.Errorf("Error fetching job for event %s", err.Error())
+			} else if gotJob.ID != job.ID {
+				t.Errorf("Event was not moved to job %s", job.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func validatePolicy(t *testing.T, exp, got *model.Policy) {
+	if got == nil {
+		t.Errorf("Expecting result, policy is nil")
+		return
+	}
+	if got.ID == "" {
+		t.Errorf("Policy id invalid.")
+	}
+	if got.TenantID != exp.TenantID {
+		t.Errorf("Policy tenant id mismatch expected %s got %s", exp.TenantID, got.TenantID)
+	}
+	if got.ProtocolType != exp.ProtocolType {
+		t.Errorf("Policy protocol mismatch expected %s got %s", exp.ProtocolType, got.ProtocolType)
+	}
+	if got.Action != exp.Action {
+		t.Errorf("Policy action mismatch expected %s got %s", exp.Action, got.Action)
+	}
+	if got.ConnectionID != exp.ConnectionID {
+		t.Errorf("Policy connection id mismatch expected %s got %s", exp.ConnectionID, got.ConnectionID)
+	}
+	if got.TheirLabel != exp.TheirLabel {
+		t.Errorf("Policy their label mismatch expected %s got %s", exp.TheirLabel, got.TheirLabel)
+	}
+	if got.CredDefID != exp.CredDefID {
+		t.Errorf("Policy cred def id mismatch expected %s got %s", exp.CredDefID, got.CredDefID)
+	}
+	if len(got.Attributes) != len(exp.Attributes) {
+		t.Errorf("Policy attributes mismatch expected %v got %v", exp.Attributes, got.Attributes)
+	}
+	validateCreatedTS(t, got.Cursor, &got.Created)
+}
+
+func findPolicy(policies []*model.Policy, id string) *model.Policy {
+	for _, p := range policies {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestPolicy(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("policy "+s.name, func(t *testing.T) {
+			policy := &model.Policy{
+				Base:         model.Base{TenantID: s.testTenantID},
+				ProtocolType: graph.ProtocolTypeCredential,
+				Action:       graph.PolicyActionAccept,
+				ConnectionID: s.testConnectionID,
+				CredDefID:    "credDefId",
+				Attributes:   []string{"name1", "name2"},
+			}
+
+			// Add data
+			p, err := s.db.AddPolicy(policy)
+			if err != nil {
+				t.Errorf("Failed to add policy %s", err.Error())
+				return
+			}
+			validatePolicy(t, policy, p)
+
+			// Update data
+			p.Action = graph.PolicyActionDecline
+			p.TheirLabel = "label"
+			p.Attributes = nil
+			updated, err := s.db.UpdatePolicy(p)
+			if err != nil {
+				t.Errorf("Failed to update policy %s", err.Error())
+			}
+			validatePolicy(t, p, updated)
+
+			// Get data for tenant
+			policies, err := s.db.GetPolicies(s.testTenantID)
+			if err != nil {
+				t.Errorf("Error fetching policies %s", err.Error())
+			}
+			validatePolicy(t, p, findPolicy(policies, p.ID))
+
+			// Remove data
+			if err = s.db.RemovePolicy(p.ID, s.testTenantID); err != nil {
+				t.Errorf("Failed to remove policy %s", err.Error())
+			}
+			policies, err = s.db.GetPolicies(s.testTenantID)
+			if err != nil {
+				t.Errorf("Error fetching policies %s", err.Error())
+			}
+			if findPolicy(policies, p.ID) != nil {
+				t.Errorf("Policy was not removed")
+			}
+		})
+	}
+}
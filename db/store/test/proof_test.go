@@ -27,6 +27,33 @@ func validateProofAttributes(t *testing.T, exp, got []*graph.ProofAttribute) {
 		if a.CredDefID != exp[index].CredDefID {
 			t.Errorf("Proof attribute cred def mismatch: expected %s got %s.", exp[index].CredDefID, a.CredDefID)
 		}
+		if len(a.Restrictions) != len(exp[index].Restrictions) {
+			t.Errorf("Proof attribute restriction count mismatch: expected %d got %d.", len(exp[index].Restrictions), len(a.Restrictions))
+		}
+	}
+}
+
+func validateProofPredicates(t *testing.T, exp, got []*graph.ProofPredicate) {
+	if len(got) != len(exp) {
+		t.Errorf("Proof predicate count mismatch: expected %d got %d", len(exp), len(got))
+		return
+	}
+	for index, p := range got {
+		if p.ID == "" {
+			t.Errorf("Proof predicate id invalid.")
+		}
+		if p.Name != exp[index].Name {
+			t.Errorf("Proof predicate name mismatch: expected %s got %s.", exp[index].Name, p.Name)
+		}
+		if p.Operator != exp[index].Operator {
+			t.Errorf("Proof predicate operator mismatch: expected %s got %s.", exp[index].Operator, p.Operator)
+		}
+		if p.Value != exp[index].Value {
+			t.Errorf("Proof predicate value mismatch: expected %d got %d.", exp[index].Value, p.Value)
+		}
+		if p.CredDefID != exp[index].CredDefID {
+			t.Errorf("Proof predicate cred def mismatch: expected %s got %s.", exp[index].CredDefID, p.CredDefID)
+		}
 	}
 }
 
@@ -77,6 +104,7 @@ func validateProof(t *testing.T, exp, got *model.Proof) {
 	validateCreatedTS(t, got.Cursor, &got.Created)
 
 	validateProofAttributes(t, exp.Attributes, got.Attributes)
+	validateProofPredicates(t, exp.Predicates, got.Predicates)
 	validateProofValues(t, exp.Values, got.Values)
 }
 
@@ -167,6 +195,35 @@ func TestAddProof(t *testing.T) {
 	}
 }
 
+func TestAddProofWithPredicates(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("add proof with predicates "+s.name, func(t *testing.T) {
+			proof := s.newTestProof(testProof)
+			proof.Predicates = []*graph.ProofPredicate{
+				{Name: "age", Operator: ">=", Value: 18, CredDefID: "credDefId"},
+				{Name: "score", Operator: "<", Value: 100, CredDefID: "credDefId"},
+			}
+
+			// Add data
+			p, err := s.db.AddProof(proof)
+			if err != nil {
+				t.Errorf("Failed to add proof %s", err.Error())
+			} else {
+				validateProof(t, proof, p)
+			}
+
+			// Get data for id
+			got, err := s.db.GetProof(p.ID, s.testTenantID)
+			if err != nil {
+				t.Errorf("Error fetching proof %s", err.Error())
+			} else {
+				validateProof(t, p, got)
+			}
+		})
+	}
+}
+
 func TestUpdateProof(t *testing.T) {
 	for index := range DBs {
 		s := DBs[index]
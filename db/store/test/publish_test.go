@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+)
+
+func TestPublishEvent(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("publish event "+s.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			events, err := s.db.ListenEvents(ctx, time.Second)
+			if err != nil {
+				t.Fatalf("Failed to listen events %s", err.Error())
+			}
+
+			published := &model.PublishedEvent{ID: "event-id", TenantID: "tenant-id", Origin: "replica"}
+			// listener connects in the background, publish until received
+			ticker := time.NewTicker(100 * time.Millisecond)
+			defer ticker.Stop()
+			timeout := time.After(5 * time.Second)
+			for received := false; !received; {
+				if err = s.db.PublishEvent(ctx, published); err != nil {
+					t.Fatalf("Failed to publish event %s", err.Error())
+				}
+				select {
+				case got := <-events:
+					if *got != *published {
+						t.Errorf("Mismatch in published event, expected %v got %v", published, got)
+					}
+					received = true
+				case <-ticker.C:
+				case <-timeout:
+					t.Fatalf("Published event was not received")
+				}
+			}
+
+			cancel()
+			for range events {
+				// drain until closed
+			}
+		})
+	}
+}
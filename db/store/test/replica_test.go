@@ -0,0 +1,54 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/store"
+)
+
+func TestReplicas(t *testing.T) {
+	for index := range DBs {
+		s := DBs[index]
+		t.Run("replicas "+s.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, id := range []string{"replica-b", "replica-a"} {
+				if err := s.db.UpdateReplica(ctx, id, ""); err != nil {
+					t.Fatalf("Failed to register replica %s", err.Error())
+				}
+			}
+			// heartbeat of existing replica
+			if err := s.db.UpdateReplica(ctx, "replica-a", "replica-a,replica-b"); err != nil {
+				t.Errorf("Failed to update replica %s", err.Error())
+			}
+
+			replicas, err := s.db.GetReplicas(ctx, time.Minute)
+			if err != nil {
+				t.Fatalf("Failed to get replicas %s", err.Error())
+			}
+			if len(replicas) != 2 || replicas[0].ID != "replica-a" || replicas[1].ID != "replica-b" {
+				t.Errorf("Mismatch in replicas, got %v", replicas)
+			} else if replicas[0].Ring != "replica-a,replica-b" || replicas[1].Ring != "" {
+				t.Errorf("Mismatch in applied rings, got %+v %+v", replicas[0], replicas[1])
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			if replicas, err = s.db.GetReplicas(ctx, time.Millisecond); err != nil || len(replicas) != 0 {
+				t.Errorf("Expecting no live replicas, got %v %v", replicas, err)
+			}
+
+			if err = s.db.RemoveReplica(ctx, "replica-a"); err != nil {
+				t.Errorf("Failed to remove replica %s", err.Error())
+			}
+			if err = s.db.RemoveReplica(ctx, "replica-a"); store.ErrorCode(err) != store.ErrCodeNotFound {
+				t.Errorf("Expecting not found error, got %v", err)
+			}
+			if replicas, err = s.db.GetReplicas(ctx, time.Minute); err != nil || len(replicas) != 1 || replicas[0].ID != "replica-b" {
+				t.Errorf("Mismatch in replicas after removal, got %v %v", replicas, err)
+			}
+			_ = s.db.RemoveReplica(ctx, "replica-b")
+		})
+	}
+}
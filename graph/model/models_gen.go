@@ -8,6 +8,14 @@ import (
 	"strconv"
 )
 
+type AgencyStatus struct {
+	Available bool         `json:"available"`
+	Degraded  bool         `json:"degraded"`
+	Breaker   BreakerState `json:"breaker"`
+	Failures  int          `json:"failures"`
+	RetryAtMs *string      `json:"retryAtMs"`
+}
+
 type BasicMessage struct {
 	ID         string    `json:"id"`
 	Message    string    `json:"message"`
@@ -66,12 +74,35 @@ type CredentialMatch struct {
 	Value        string `json:"value"`
 }
 
+type CredentialOfferInput struct {
+	ConnectionID string                  `json:"connectionId"`
+	CredDefID    string                  `json:"credDefId"`
+	Attributes   []*CredentialValueInput `json:"attributes"`
+}
+
 type CredentialValue struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Value string `json:"value"`
 }
 
+type CredentialValueInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type DeadLetter struct {
+	ID            string       `json:"id"`
+	TenantID      string       `json:"tenantId"`
+	ProtocolID    string       `json:"protocolId"`
+	Protocol      ProtocolType `json:"protocol"`
+	ConnectionID  *string      `json:"connectionId"`
+	Attempts      int          `json:"attempts"`
+	LastError     string       `json:"lastError"`
+	CreatedMs     string       `json:"createdMs"`
+	NextAttemptMs string       `json:"nextAttemptMs"`
+}
+
 type Event struct {
 	ID          string    `json:"id"`
 	Read        bool      `json:"read"`
@@ -108,8 +139,11 @@ type Job struct {
 	InitiatedByUs bool         `json:"initiatedByUs"`
 	Status        JobStatus    `json:"status"`
 	Result        JobResult    `json:"result"`
+	Failure       *JobFailure  `json:"failure"`
 	CreatedMs     string       `json:"createdMs"`
 	UpdatedMs     string       `json:"updatedMs"`
+	ExpiresMs     *string      `json:"expiresMs"`
+	Attempts      int          `json:"attempts"`
 	Output        *JobOutput   `json:"output"`
 }
 
@@ -127,6 +161,11 @@ type JobEdge struct {
 	Node   *Job   `json:"node"`
 }
 
+type JobFailure struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type JobOutput struct {
 	Connection *PairwiseEdge     `json:"connection"`
 	Message    *BasicMessageEdge `json:"message"`
@@ -134,6 +173,13 @@ type JobOutput struct {
 	Proof      *ProofEdge        `json:"proof"`
 }
 
+type LeaderStatus struct {
+	Instance  string  `json:"instance"`
+	Candidate bool    `json:"candidate"`
+	IsLeader  bool    `json:"isLeader"`
+	Leader    *string `json:"leader"`
+}
+
 type LoginResponse struct {
 	Token string `json:"token"`
 }
@@ -155,19 +201,21 @@ type PageInfo struct {
 }
 
 type Pairwise struct {
-	ID            string                  `json:"id"`
-	OurDid        string                  `json:"ourDid"`
-	TheirDid      string                  `json:"theirDid"`
-	TheirEndpoint string                  `json:"theirEndpoint"`
-	TheirLabel    string                  `json:"theirLabel"`
-	CreatedMs     string                  `json:"createdMs"`
-	ApprovedMs    string                  `json:"approvedMs"`
-	Invited       bool                    `json:"invited"`
-	Messages      *BasicMessageConnection `json:"messages"`
-	Credentials   *CredentialConnection   `json:"credentials"`
-	Proofs        *ProofConnection        `json:"proofs"`
-	Jobs          *JobConnection          `json:"jobs"`
-	Events        *EventConnection        `json:"events"`
+	ID             string                  `json:"id"`
+	OurDid         string                  `json:"ourDid"`
+	TheirDid       string                  `json:"theirDid"`
+	TheirEndpoint  string                  `json:"theirEndpoint"`
+	TheirLabel     string                  `json:"theirLabel"`
+	CreatedMs      string                  `json:"createdMs"`
+	ApprovedMs     string                  `json:"approvedMs"`
+	Invited        bool                    `json:"invited"`
+	LastSeenMs     *string                 `json:"lastSeenMs"`
+	LastPingResult *bool                   `json:"lastPingResult"`
+	Messages       *BasicMessageConnection `json:"messages"`
+	Credentials    *CredentialConnection   `json:"credentials"`
+	Proofs         *ProofConnection        `json:"proofs"`
+	Jobs           *JobConnection          `json:"jobs"`
+	Events         *EventConnection        `json:"events"`
 }
 
 type PairwiseConnection struct {
@@ -182,10 +230,22 @@ type PairwiseEdge struct {
 	Node   *Pairwise `json:"node"`
 }
 
+type PolicyInput struct {
+	ID           *string      `json:"id"`
+	Protocol     ProtocolType `json:"protocol"`
+	Action       PolicyAction `json:"action"`
+	ConnectionID *string      `json:"connectionId"`
+	TheirLabel   *string      `json:"theirLabel"`
+	TheirDid     *string      `json:"theirDid"`
+	CredDefID    *string      `json:"credDefId"`
+	Attributes   []string     `json:"attributes"`
+}
+
 type Proof struct {
 	ID            string            `json:"id"`
 	Role          ProofRole         `json:"role"`
 	Attributes    []*ProofAttribute `json:"attributes"`
+	Predicates    []*ProofPredicate `json:"predicates"`
 	Values        []*ProofValue     `json:"values"`
 	Provable      *Provable         `json:"provable"`
 	InitiatedByUs bool              `json:"initiatedByUs"`
@@ -197,9 +257,10 @@ type Proof struct {
 }
 
 type ProofAttribute struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CredDefID string `json:"credDefId"`
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	CredDefID    string              `json:"credDefId"`
+	Restrictions []*ProofRestriction `json:"restrictions"`
 }
 
 type ProofConnection struct {
@@ -215,14 +276,47 @@ type ProofEdge struct {
 	Node   *Proof `json:"node"`
 }
 
-type ProofRequestAttribute struct {
+type ProofPredicate struct {
+	ID        string `json:"id"`
 	Name      string `json:"name"`
+	Operator  string `json:"operator"`
+	Value     int    `json:"value"`
 	CredDefID string `json:"credDefId"`
 }
 
+type ProofRequestAttribute struct {
+	Name         string                   `json:"name"`
+	CredDefID    *string                  `json:"credDefId"`
+	Restrictions []*ProofRestrictionInput `json:"restrictions"`
+}
+
 type ProofRequestInput struct {
 	ConnectionID string                   `json:"connectionId"`
 	Attributes   []*ProofRequestAttribute `json:"attributes"`
+	Predicates   []*ProofRequestPredicate `json:"predicates"`
+}
+
+type ProofRequestPredicate struct {
+	Name      string `json:"name"`
+	Operator  string `json:"operator"`
+	Value     int    `json:"value"`
+	CredDefID string `json:"credDefId"`
+}
+
+type ProofRestriction struct {
+	SchemaID        string `json:"schemaId"`
+	SchemaIssuerDid string `json:"schemaIssuerDid"`
+	SchemaName      string `json:"schemaName"`
+	IssuerDid       string `json:"issuerDid"`
+	CredDefID       string `json:"credDefId"`
+}
+
+type ProofRestrictionInput struct {
+	SchemaID        *string `json:"schemaId"`
+	SchemaIssuerDid *string `json:"schemaIssuerDid"`
+	SchemaName      *string `json:"schemaName"`
+	IssuerDid       *string `json:"issuerDid"`
+	CredDefID       *string `json:"credDefId"`
 }
 
 type ProofValue struct {
@@ -252,11 +346,72 @@ type ResumeJobInput struct {
 	Accept bool   `json:"accept"`
 }
 
+type SystemStatus struct {
+	Version    string        `json:"version"`
+	Agency     *AgencyStatus `json:"agency"`
+	HookLeader *LeaderStatus `json:"hookLeader"`
+}
+
+type TenantPolicy struct {
+	ID           string       `json:"id"`
+	Protocol     ProtocolType `json:"protocol"`
+	Action       PolicyAction `json:"action"`
+	ConnectionID string       `json:"connectionId"`
+	TheirLabel   string       `json:"theirLabel"`
+	TheirDid     string       `json:"theirDid"`
+	CredDefID    string       `json:"credDefId"`
+	Attributes   []string     `json:"attributes"`
+	CreatedMs    string       `json:"createdMs"`
+}
+
 type User struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
+type BreakerState string
+
+const (
+	BreakerStateClosed   BreakerState = "CLOSED"
+	BreakerStateOpen     BreakerState = "OPEN"
+	BreakerStateHalfOpen BreakerState = "HALF_OPEN"
+)
+
+var AllBreakerState = []BreakerState{
+	BreakerStateClosed,
+	BreakerStateOpen,
+	BreakerStateHalfOpen,
+}
+
+func (e BreakerState) IsValid() bool {
+	switch e {
+	case BreakerStateClosed, BreakerStateOpen, BreakerStateHalfOpen:
+		return true
+	}
+	return false
+}
+
+func (e BreakerState) String() string {
+	return string(e)
+}
+
+func (e *BreakerState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BreakerState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BreakerState", str)
+	}
+	return nil
+}
+
+func (e BreakerState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type CredentialRole string
 
 const (
@@ -301,20 +456,24 @@ func (e CredentialRole) MarshalGQL(w io.Writer) {
 type JobResult string
 
 const (
-	JobResultNone    JobResult = "NONE"
-	JobResultSuccess JobResult = "SUCCESS"
-	JobResultFailure JobResult = "FAILURE"
+	JobResultNone      JobResult = "NONE"
+	JobResultSuccess   JobResult = "SUCCESS"
+	JobResultFailure   JobResult = "FAILURE"
+	JobResultCancelled JobResult = "CANCELLED"
+	JobResultExpired   JobResult = "EXPIRED"
 )
 
 var AllJobResult = []JobResult{
 	JobResultNone,
 	JobResultSuccess,
 	JobResultFailure,
+	JobResultCancelled,
+	JobResultExpired,
 }
 
 func (e JobResult) IsValid() bool {
 	switch e {
-	case JobResultNone, JobResultSuccess, JobResultFailure:
+	case JobResultNone, JobResultSuccess, JobResultFailure, JobResultCancelled, JobResultExpired:
 		return true
 	}
 	return false
@@ -386,6 +545,47 @@ func (e JobStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type PolicyAction string
+
+const (
+	PolicyActionAccept  PolicyAction = "ACCEPT"
+	PolicyActionDecline PolicyAction = "DECLINE"
+)
+
+var AllPolicyAction = []PolicyAction{
+	PolicyActionAccept,
+	PolicyActionDecline,
+}
+
+func (e PolicyAction) IsValid() bool {
+	switch e {
+	case PolicyActionAccept, PolicyActionDecline:
+		return true
+	}
+	return false
+}
+
+func (e PolicyAction) String() string {
+	return string(e)
+}
+
+func (e *PolicyAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PolicyAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PolicyAction", str)
+	}
+	return nil
+}
+
+func (e PolicyAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ProofRole string
 
 const (
@@ -435,6 +635,7 @@ const (
 	ProtocolTypeCredential   ProtocolType = "CREDENTIAL"
 	ProtocolTypeProof        ProtocolType = "PROOF"
 	ProtocolTypeBasicMessage ProtocolType = "BASIC_MESSAGE"
+	ProtocolTypeTrustPing    ProtocolType = "TRUST_PING"
 )
 
 var AllProtocolType = []ProtocolType{
@@ -443,11 +644,12 @@ var AllProtocolType = []ProtocolType{
 	ProtocolTypeCredential,
 	ProtocolTypeProof,
 	ProtocolTypeBasicMessage,
+	ProtocolTypeTrustPing,
 }
 
 func (e ProtocolType) IsValid() bool {
 	switch e {
-	case ProtocolTypeNone, ProtocolTypeConnection, ProtocolTypeCredential, ProtocolTypeProof, ProtocolTypeBasicMessage:
+	case ProtocolTypeNone, ProtocolTypeConnection, ProtocolTypeCredential, ProtocolTypeProof, ProtocolTypeBasicMessage, ProtocolTypeTrustPing:
 		return true
 	}
 	return false
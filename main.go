@@ -28,7 +28,6 @@ func main() {
 	}
 
 	gqlResolver := resolver.InitResolver(config, &findy.Agency{})
-	defer gqlResolver.Close()
 
 	srv := server.NewServer(gqlResolver, config.JWTKey)
 	http.Handle("/query", srv.Handle())
@@ -41,13 +40,14 @@ func main() {
 		}
 		_, _ = w.Write([]byte(config.Version))
 	})
-	startServer(config.Address)
+	http.HandleFunc("/health/status", server.HealthStatusHandler(gqlResolver.Status))
+	startServer(config, gqlResolver)
 }
 
-func startServer(address string) {
+func startServer(config *utils.Configuration, gqlResolver *resolver.Resolver) {
 	const serverTimeout = 5 * time.Second
 	ourServer := &http.Server{
-		Addr:              address,
+		Addr:              config.Address,
 		ReadHeaderTimeout: serverTimeout,
 	}
 
@@ -63,11 +63,21 @@ func startServer(address string) {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
-	shutdownCtx, shutdownRelease := context.WithTimeout(context.Background(), serverTimeout)
+	shutdownCtx, shutdownRelease := context.WithTimeout(
+		context.Background(),
+		time.Duration(config.ShutdownTimeout)*time.Second,
+	)
 	defer shutdownRelease()
 
+	// stop accepting new requests and wait for the active ones,
+	// websocket subscriptions are left open until the resolver closes them
 	if err := ourServer.Shutdown(shutdownCtx); err != nil {
 		glog.Errorf("HTTP shutdown error: %v", err)
+	}
+
+	// drain agency notifications and end subscriptions
+	if err := gqlResolver.Close(shutdownCtx); err != nil {
+		glog.Errorf("Shutdown error: %v", err)
 	} else {
 		glog.Infoln("Graceful shutdown complete.")
 	}
@@ -0,0 +1,146 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+const (
+	maxAttempts    = 10
+	baseRetryDelay = 10 * time.Second
+	maxRetryDelay  = time.Hour
+	dueBatchSize   = 50
+	// claimLease keeps the letter from other workers during a retry,
+	// it must exceed the time it takes to process a notification
+	claimLease = 5 * time.Minute
+)
+
+// Queue retries agency notifications whose processing has failed.
+// Letters exceeding the maximum attempts are left for the user to retry or discard.
+type Queue struct {
+	db     store.DB
+	agency agency.Agency
+	*agent.Resolver
+}
+
+func NewQueue(db store.DB, agencyInstance agency.Agency, agentResolver *agent.Resolver) *Queue {
+	return &Queue{db, agencyInstance, agentResolver}
+}
+
+func retryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return baseRetryDelay
+	}
+	delay := baseRetryDelay << (attempts - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// Retry processes the claimed letter again. The letter is removed when processing succeeds.
+// Failures caused by the agency breaker do not use up the attempts, as the letter was never processed.
+func (q *Queue) Retry(ctx context.Context, a *agency.Agent, letter *dbModel.DeadLetter) (err error) {
+	defer err2.Handle(&err)
+
+	letter.Attempts++
+	if processErr := q.agency.ProcessDeadLetter(ctx, a, letter); processErr != nil {
+		if errors.Is(processErr, agency.ErrUnavailable) {
+			letter.Attempts--
+		}
+		glog.Warningf("Retry %d for dead letter %s failed: %s", letter.Attempts, letter.ID, processErr.Error())
+		letter.LastError = processErr.Error()
+		letter.NextAttempt = time.Now().UTC().Add(retryDelay(letter.Attempts))
+		try.To1(q.db.UpdateDeadLetter(letter))
+		return processErr
+	}
+
+	utils.LogMed().Infof("Processed dead letter %s after %d attempts", letter.ID, letter.Attempts)
+	try.To(q.db.RemoveDeadLetter(letter.ID, letter.TenantID))
+	return nil
+}
+
+// RetryNow claims the letter and processes it right away regardless of its retry schedule
+func (q *Queue) RetryNow(ctx context.Context, id string) (err error) {
+	defer err2.Handle(&err)
+
+	letter, err := q.db.ClaimDeadLetter(id, time.Now().UTC().Add(claimLease))
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		return fmt.Errorf("dead letter %s does not exist or is being retried", id)
+	}
+	try.To(err)
+
+	tenant := try.To1(q.db.GetAgent(&letter.TenantID, nil))
+	return q.Retry(ctx, q.BackgroundAuth(tenant), letter)
+}
+
+// Discard drops the letter without processing it. The letter is claimed first
+// so that a letter being retried by a worker is not removed under it.
+func (q *Queue) Discard(id string) (err error) {
+	defer err2.Handle(&err)
+
+	letter, err := q.db.ClaimDeadLetter(id, time.Now().UTC().Add(claimLease))
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		return fmt.Errorf("dead letter %s does not exist or is being retried", id)
+	}
+	try.To(err)
+
+	utils.LogMed().Infof("Discard dead letter %s for tenant %s", id, letter.TenantID)
+	try.To(q.db.RemoveDeadLetter(id, letter.TenantID))
+	return nil
+}
+
+// Run retries the due letters periodically until the context is done
+func (q *Queue) Run(ctx context.Context, interval time.Duration) {
+	utils.LogMed().Infof("Start dead letter worker with interval %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogMed().Infoln("Stop dead letter worker")
+			return
+		case <-ticker.C:
+		}
+		if count := q.RetryDue(); count > 0 {
+			utils.LogMed().Infof("Processed %d dead letters", count)
+		}
+	}
+}
+
+// RetryDue retries the letters whose retry delay has passed.
+// Each letter is claimed before the retry so that concurrent workers do not process the same letter.
+func (q *Queue) RetryDue() (processed int) {
+	for i := 0; i < dueBatchSize; i++ {
+		now := time.Now().UTC()
+		letter, err := q.db.ClaimDueDeadLetter(now, now.Add(claimLease), maxAttempts)
+		if err != nil {
+			if store.ErrorCode(err) != store.ErrCodeNotFound {
+				glog.Errorf("Unable to claim dead letter: %s", err.Error())
+			}
+			return processed
+		}
+
+		tenant, err := q.db.GetAgent(&letter.TenantID, nil)
+		if err != nil {
+			glog.Errorf("Unable to fetch tenant %s for dead letter %s: %s", letter.TenantID, letter.ID, err.Error())
+			continue
+		}
+		if q.Retry(context.Background(), q.BackgroundAuth(tenant), letter) == nil {
+			processed++
+		}
+	}
+	return processed
+}
@@ -0,0 +1,144 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	agencyMock "github.com/findy-network/findy-agent-vault/agency/mock"
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	storeMock "github.com/findy-network/findy-agent-vault/db/store/mock"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	gomock "github.com/golang/mock/gomock"
+)
+
+const testTenantID = "tenant-id"
+
+var testAgent = &agency.Agent{TenantID: testTenantID}
+
+func createQueue(t *testing.T) (*Queue, *storeMock.MockDB, *agencyMock.MockAgency) {
+	ctrl := gomock.NewController(t)
+	m := storeMock.NewMockDB(ctrl)
+	a := agencyMock.NewMockAgency(ctrl)
+	return NewQueue(m, a, agent.NewResolver(m, a, "")), m, a
+}
+
+func testLetter(attempts int) *dbModel.DeadLetter {
+	return &dbModel.DeadLetter{
+		Base:       dbModel.Base{ID: "letter-id", TenantID: testTenantID},
+		ProtocolID: "protocol-id",
+		Attempts:   attempts,
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		exp      time.Duration
+	}{
+		{0, baseRetryDelay},
+		{1, baseRetryDelay},
+		{2, 2 * baseRetryDelay},
+		{3, 4 * baseRetryDelay},
+		{20, maxRetryDelay},
+		{100, maxRetryDelay},
+	}
+	for _, tc := range tests {
+		if got := retryDelay(tc.attempts); got != tc.exp {
+			t.Errorf("Mismatch in retry delay for %d attempts, expected: %v got: %v", tc.attempts, tc.exp, got)
+		}
+	}
+}
+
+func TestRetryAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expAttempts int
+	}{
+		// breaker failures do not use up the attempts as the letter was not processed
+		{"agency unavailable", fmt.Errorf("call failed: %w", agency.ErrUnavailable), 2},
+		{"processing failure", errors.New("db failure"), 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, m, a := createQueue(t)
+			letter := testLetter(2)
+
+			a.
+				EXPECT().
+				ProcessDeadLetter(gomock.Any(), testAgent, letter).
+				Return(tc.err)
+			m.
+				EXPECT().
+				UpdateDeadLetter(gomock.Any()).
+				DoAndReturn(func(letter *dbModel.DeadLetter) (*dbModel.DeadLetter, error) {
+					if letter.Attempts != tc.expAttempts {
+						t.Errorf("Mismatch in attempts, expected: %d got: %d", tc.expAttempts, letter.Attempts)
+					}
+					if letter.LastError != tc.err.Error() || letter.NextAttempt.Before(time.Now().UTC()) {
+						t.Errorf("Failed retry not rescheduled: %+v", letter)
+					}
+					return letter, nil
+				})
+
+			if err := q.Retry(context.Background(), testAgent, letter); !errors.Is(err, tc.err) {
+				t.Errorf("Expected retry error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRetryRemovesLetter(t *testing.T) {
+	q, m, a := createQueue(t)
+	letter := testLetter(1)
+
+	a.
+		EXPECT().
+		ProcessDeadLetter(gomock.Any(), testAgent, letter).
+		Return(nil)
+	m.
+		EXPECT().
+		RemoveDeadLetter(letter.ID, testTenantID).
+		Return(nil)
+
+	if err := q.Retry(context.Background(), testAgent, letter); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	q, m, _ := createQueue(t)
+	letter := testLetter(1)
+
+	m.
+		EXPECT().
+		ClaimDeadLetter(letter.ID, gomock.Any()).
+		Return(letter, nil)
+	m.
+		EXPECT().
+		RemoveDeadLetter(letter.ID, testTenantID).
+		Return(nil)
+
+	if err := q.Discard(letter.ID); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+}
+
+func TestDiscardClaimed(t *testing.T) {
+	q, m, _ := createQueue(t)
+
+	// letter is being retried by another worker, it is not removed
+	m.
+		EXPECT().
+		ClaimDeadLetter("letter-id", gomock.Any()).
+		Return(nil, store.NewError(store.ErrCodeNotFound, "no rows returned"))
+
+	if err := q.Discard("letter-id"); err == nil {
+		t.Errorf("Expecting error when discarding claimed letter")
+	}
+}
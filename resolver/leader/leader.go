@@ -0,0 +1,176 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+// hookLockKey identifies the advisory lock of the PSM hook subscriber
+const hookLockKey int64 = 0x5053_4d48 // "PSMH"
+
+// Elector elects the replica that subscribes to the agency PSM hook.
+// The leader holds a Postgres advisory lock, the lock is freed automatically
+// when the leader dies or loses its database connection and some other candidate takes over.
+// The hook is stopped as soon as the lock connection is found broken.
+// Replicas that are not candidates only keep track of the current leader.
+type Elector struct {
+	db         store.DB
+	agency     agency.Agency
+	instanceID string
+	candidate  bool
+
+	// lock and stopHook are used only by the election loop
+	lock     store.Lock
+	stopHook context.CancelFunc
+
+	mu       sync.Mutex
+	leader   string
+	isLeader bool
+}
+
+func NewElector(db store.DB, agencyInstance agency.Agency, instanceID string, candidate bool) *Elector {
+	return &Elector{db: db, agency: agencyInstance, instanceID: instanceID, candidate: candidate}
+}
+
+// Run checks the leadership with the interval until the context is done.
+// The leadership is released on exit.
+func (e *Elector) Run(ctx context.Context, interval time.Duration) {
+	utils.LogMed().Infof("Start leader election for %s with interval %v (candidate: %v)", e.instanceID, interval, e.candidate)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.elect(ctx)
+		select {
+		case <-ctx.Done():
+			e.stepDown()
+			utils.LogMed().Infoln("Stop leader election")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) elect(ctx context.Context) {
+	if e.lock != nil {
+		err := e.lock.Check(ctx)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		glog.Warningf("Instance %s lost the PSM hook leadership: %s", e.instanceID, err.Error())
+		e.stepDown()
+	}
+
+	if e.candidate {
+		lock, err := e.db.TryLock(ctx, hookLockKey, e.instanceID)
+		if err != nil {
+			glog.Errorf("Unable to acquire PSM hook leadership: %s", err.Error())
+			return
+		}
+		if lock != nil {
+			e.takeLead(ctx, lock)
+			return
+		}
+	}
+
+	holder, err := e.db.GetLockHolder(ctx, hookLockKey)
+	if err != nil {
+		glog.Errorf("Unable to fetch PSM hook leader: %s", err.Error())
+		return
+	}
+	e.setLeader(holder, false)
+}
+
+func (e *Elector) takeLead(ctx context.Context, lock store.Lock) {
+	hookCtx, cancel := context.WithCancel(ctx)
+	if err := e.agency.ListenHook(hookCtx); err != nil {
+		glog.Errorf("Unable to subscribe to PSM hook, releasing leadership: %s", err.Error())
+		cancel()
+		e.release(lock)
+		return
+	}
+
+	glog.Infof("Instance %s elected as the PSM hook subscriber", e.instanceID)
+	e.lock = lock
+	e.stopHook = cancel
+	e.setLeader(e.instanceID, true)
+
+	// database frees the lock of a broken session, stop the hook right away
+	// instead of waiting for the next check
+	go func() {
+		select {
+		case <-lock.Lost():
+			glog.Warningf("Instance %s lost the PSM hook lock connection, stopping hook", e.instanceID)
+			cancel()
+		case <-hookCtx.Done():
+		}
+	}()
+}
+
+func (e *Elector) stepDown() {
+	if e.lock == nil {
+		return
+	}
+
+	utils.LogMed().Infof("Instance %s stepping down as the PSM hook subscriber", e.instanceID)
+	// hook is stopped before releasing the lock, so stepping down does not overlap with the next leader.
+	// When the session is lost, the lock may be taken over before the broken connection is noticed:
+	// the hook is stopped within the lock watch interval and the subscribers may overlap for that time.
+	e.stopHook()
+	e.release(e.lock)
+	e.lock = nil
+	e.stopHook = nil
+	e.setLeader("", false)
+}
+
+func (e *Elector) release(lock store.Lock) {
+	if err := lock.Release(); err != nil {
+		glog.Warningf("Unable to release PSM hook lock: %s", err.Error())
+	}
+}
+
+func (e *Elector) setLeader(leader string, isLeader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.leader != leader {
+		utils.LogLow().Infof("PSM hook leader changed from '%s' to '%s'", e.leader, leader)
+	}
+	e.leader = leader
+	e.isLeader = isLeader
+}
+
+// Status returns the leader status as seen by this instance on the latest check
+func (e *Elector) Status() *model.LeaderStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var leader *string
+	if e.leader != "" {
+		value := e.leader
+		leader = &value
+	}
+	return &model.LeaderStatus{
+		Instance:  e.instanceID,
+		Leader:    leader,
+		IsLeader:  e.isLeader,
+		Candidate: e.candidate,
+	}
+}
+
+// IsLeader reports if this instance held the leadership on the latest check
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.isLeader
+}
@@ -0,0 +1,189 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/mock"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/golang/mock/gomock"
+)
+
+// lockDB imitates the advisory lock of the database, other store methods are not used
+type lockDB struct {
+	store.DB
+	sync.Mutex
+	holder string
+}
+
+type testLock struct {
+	db       *lockDB
+	holder   string
+	lost     bool
+	released bool
+	broken   chan struct{}
+}
+
+func (d *lockDB) TryLock(_ context.Context, _ int64, holder string) (store.Lock, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.holder != "" {
+		return nil, nil
+	}
+	d.holder = holder
+	return &testLock{db: d, holder: holder, broken: make(chan struct{})}, nil
+}
+
+func (d *lockDB) GetLockHolder(_ context.Context, _ int64) (string, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.holder, nil
+}
+
+func (l *testLock) Check(_ context.Context) error {
+	if l.lost {
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func (l *testLock) Lost() <-chan struct{} {
+	return l.broken
+}
+
+func (l *testLock) Release() error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	l.released = true
+	if l.db.holder == l.holder {
+		l.db.holder = ""
+	}
+	return nil
+}
+
+// lose imitates losing the database session: the lock is freed but the holder does not know it yet
+func (l *testLock) lose() {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	l.lost = true
+	l.db.holder = ""
+}
+
+func TestElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := &lockDB{}
+
+	agency1 := mock.NewMockAgency(ctrl)
+	agency2 := mock.NewMockAgency(ctrl)
+	var hook1 context.Context
+	agency1.EXPECT().ListenHook(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		hook1 = ctx
+		return nil
+	})
+
+	first := NewElector(db, agency1, "first", true)
+	second := NewElector(db, agency2, "second", true)
+	observer := NewElector(db, nil, "observer", false)
+
+	ctx := context.Background()
+	first.elect(ctx)
+	second.elect(ctx)
+	observer.elect(ctx)
+
+	if status := first.Status(); !status.IsLeader || status.Leader == nil || *status.Leader != "first" {
+		t.Errorf("Expecting first instance to be the leader, got %+v", status)
+	}
+	for _, e := range []*Elector{second, observer} {
+		if status := e.Status(); status.IsLeader || status.Leader == nil || *status.Leader != "first" {
+			t.Errorf("Expecting %s to follow the first instance, got %+v", e.instanceID, status)
+		}
+	}
+
+	// failover
+	lock := first.lock.(*testLock)
+	lock.lose()
+	agency2.EXPECT().ListenHook(gomock.Any()).Return(nil)
+	second.elect(ctx)
+	first.elect(ctx)
+
+	if hook1.Err() == nil {
+		t.Errorf("Expecting hook of the first instance to be stopped")
+	}
+	if !lock.released {
+		t.Errorf("Expecting lost lock to be released")
+	}
+	if status := second.Status(); !status.IsLeader {
+		t.Errorf("Expecting second instance to take over, got %+v", status)
+	}
+	if status := first.Status(); status.IsLeader || status.Leader == nil || *status.Leader != "second" {
+		t.Errorf("Expecting first instance to follow the second instance, got %+v", status)
+	}
+}
+
+func TestElectionHookFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := &lockDB{}
+
+	agency := mock.NewMockAgency(ctrl)
+	agency.EXPECT().ListenHook(gomock.Any()).Return(errors.New("agency down"))
+
+	e := NewElector(db, agency, "first", true)
+	e.elect(context.Background())
+
+	if e.lock != nil || db.holder != "" {
+		t.Errorf("Expecting lock to be released when hook fails")
+	}
+	if status := e.Status(); status.IsLeader || status.Leader != nil {
+		t.Errorf("Expecting no leader, got %+v", status)
+	}
+}
+
+func TestRunStepsDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := &lockDB{}
+
+	agency := mock.NewMockAgency(ctrl)
+	agency.EXPECT().ListenHook(gomock.Any()).Return(nil)
+
+	e := NewElector(db, agency, "first", true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.Run(ctx, time.Hour)
+
+	if e.lock != nil || db.holder != "" {
+		t.Errorf("Expecting leadership to be released on exit")
+	}
+}
+
+func TestElectionLockConnectionLost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := &lockDB{}
+
+	agency := mock.NewMockAgency(ctrl)
+	hook := make(chan context.Context, 1)
+	agency.EXPECT().ListenHook(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		hook <- ctx
+		return nil
+	})
+
+	e := NewElector(db, agency, "first", true)
+	e.elect(context.Background())
+	hookCtx := <-hook
+
+	// hook is stopped without waiting for the next leadership check
+	lock := e.lock.(*testLock)
+	lock.lose()
+	close(lock.broken)
+	select {
+	case <-hookCtx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("Expecting hook to be stopped when the lock connection is lost")
+	}
+}
@@ -1,13 +1,17 @@
 package listen
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
+	"github.com/findy-network/findy-agent-vault/resolver/outbox"
 	"github.com/findy-network/findy-agent-vault/resolver/update"
 	"github.com/findy-network/findy-agent-vault/utils"
 	"github.com/golang/glog"
@@ -16,15 +20,24 @@ import (
 )
 
 type Listener struct {
-	db store.DB
+	db     store.DB
+	agency agency.Agency
 	*update.Updater
+
+	// jobs resumed by policies in background
+	resumes sync.WaitGroup
+}
+
+func NewListener(db store.DB, agencyInstance agency.Agency, updater *update.Updater) *Listener {
+	return &Listener{db: db, agency: agencyInstance, Updater: updater}
 }
 
-func NewListener(db store.DB, updater *update.Updater) *Listener {
-	return &Listener{db, updater}
+// Wait waits for the jobs resumed by policies to complete or the context to be done
+func (l *Listener) Wait(ctx context.Context) error {
+	return utils.Wait(ctx, &l.resumes)
 }
 
-func (l *Listener) AddConnection(info *agency.JobInfo, data *agency.Connection) (err error) {
+func (l *Listener) addConnection(info *agency.JobInfo, data *agency.Connection) (err error) {
 	defer err2.Handle(&err)
 
 	utils.LogMed().Infof("Add connection %s for tenant %s", info.ConnectionID, info.TenantID)
@@ -61,7 +74,7 @@ func (l *Listener) AddConnection(info *agency.JobInfo, data *agency.Connection)
 	return nil
 }
 
-func (l *Listener) AddMessage(info *agency.JobInfo, data *agency.Message) (err error) {
+func (l *Listener) addMessage(info *agency.JobInfo, data *agency.Message) (job *dbModel.Job, err error) {
 	defer err2.Handle(&err)
 
 	msg := try.To1(l.db.AddMessage(&dbModel.Message{
@@ -71,24 +84,63 @@ func (l *Listener) AddMessage(info *agency.JobInfo, data *agency.Message) (err e
 		SentByMe:     data.SentByMe,
 	}))
 
-	_ = try.To1(l.AddJob(&dbModel.Job{
+	utils.LogMed().Infof("Add message %s for tenant %s", msg.ID, info.TenantID)
+
+	// messages sent by us are waiting for delivery
+	status := model.JobStatusComplete
+	result := model.JobResultSuccess
+	if data.SentByMe {
+		status = model.JobStatusWaiting
+		result = model.JobResultNone
+	}
+
+	return l.AddJob(&dbModel.Job{
 		Base:              dbModel.Base{ID: info.JobID, TenantID: info.TenantID},
 		ConnectionID:      &info.ConnectionID,
 		ProtocolType:      model.ProtocolTypeBasicMessage,
 		ProtocolMessageID: &msg.ID,
 		InitiatedByUs:     data.SentByMe,
-		Status:            model.JobStatusComplete,
-		Result:            model.JobResultSuccess,
-	}, msg.Description()))
-	return nil
+		Status:            status,
+		Result:            result,
+	}, msg.Description())
 }
 
-func (l *Listener) UpdateMessage(_ *agency.JobInfo, _ *agency.MessageUpdate) (err error) {
-	// TODO: linter needs comment, implement later
+func (l *Listener) updateMessage(info *agency.JobInfo, messageInput *agency.Message, data *agency.MessageUpdate) (err error) {
+	defer err2.Handle(&err)
+
+	job, err := l.db.GetJob(info.JobID, info.TenantID)
+	if err != nil {
+		if messageInput != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+			// message might have been sent without vault
+			utils.LogHigh().Infof("Adding message on update for tenant %s", info.TenantID)
+			job = try.To1(l.addMessage(info, messageInput))
+		} else {
+			return err
+		}
+	}
+	if isClosed(job) {
+		return nil
+	}
+
+	utils.LogMed().Infof("Update message %s for tenant %s", *job.ProtocolMessageID, info.TenantID)
+
+	msg := try.To1(l.db.GetMessage(*job.ProtocolMessageID, job.TenantID))
+
+	msg.Delivered = &data.Delivered
+	msg = try.To1(l.db.UpdateMessage(msg))
+
+	job.Status = model.JobStatusComplete
+	job.Result = model.JobResultSuccess
+	if !data.Delivered {
+		job.Result = model.JobResultFailure
+	}
+	setJobFailure(job, data.Failure)
+
+	try.To(l.UpdateJob(job, msg.Description()))
 	return nil
 }
 
-func (l *Listener) AddCredential(info *agency.JobInfo, data *agency.Credential) (job *dbModel.Job, err error) {
+func (l *Listener) addCredential(info *agency.JobInfo, data *agency.Credential) (job *dbModel.Job, err error) {
 	defer err2.Handle(&err)
 
 	credential := try.To1(l.db.AddCredential(&dbModel.Credential{
@@ -108,7 +160,7 @@ func (l *Listener) AddCredential(info *agency.JobInfo, data *agency.Credential)
 		status = model.JobStatusPending
 	}
 
-	return l.AddJob(&dbModel.Job{
+	job = try.To1(l.AddJob(&dbModel.Job{
 		Base:                 dbModel.Base{ID: info.JobID, TenantID: info.TenantID},
 		ConnectionID:         &info.ConnectionID,
 		ProtocolType:         model.ProtocolTypeCredential,
@@ -116,10 +168,97 @@ func (l *Listener) AddCredential(info *agency.JobInfo, data *agency.Credential)
 		InitiatedByUs:        data.InitiatedByUs,
 		Status:               status,
 		Result:               model.JobResultNone,
-	}, credential.Description())
+	}, credential.Description()))
+
+	if status == model.JobStatusPending && credential.Role == model.CredentialRoleHolder {
+		names := make([]string, len(credential.Attributes))
+		for i, attr := range credential.Attributes {
+			names[i] = attr.Name
+		}
+		l.applyPolicy(info, job, &dbModel.PolicyTarget{
+			ProtocolType: model.ProtocolTypeCredential,
+			CredDefIDs:   []string{credential.CredDefID},
+			Attributes:   names,
+		}, true)
+	}
+	return job, nil
+}
+
+// applyPolicy evaluates tenant policies for received credential offer or proof request.
+// First matching policy resumes the job automatically, otherwise job is left pending for the user.
+// The job is resumed in background so that the notification handling does not wait for the agency.
+func (l *Listener) applyPolicy(info *agency.JobInfo, job *dbModel.Job, target *dbModel.PolicyTarget, canAccept bool) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Warningf("Unable to apply policy for job %s, tenant %s: %s", info.JobID, info.TenantID, err)
+	}))
+
+	policies := try.To1(l.db.GetPolicies(info.TenantID))
+	if len(policies) == 0 {
+		return
+	}
+
+	connection := try.To1(l.db.GetConnection(info.ConnectionID, info.TenantID))
+	target.ConnectionID = connection.ID
+	target.TheirLabel = connection.TheirLabel
+	target.TheirDid = connection.TheirDid
+
+	var policy *dbModel.Policy
+	for _, p := range policies {
+		if p.Matches(target) {
+			policy = p
+			break
+		}
+	}
+	if policy == nil {
+		return
+	}
+
+	accept := policy.Action == model.PolicyActionAccept
+	if accept && !canAccept {
+		utils.LogMed().Infof("Policy %s matched job %s but job cannot be accepted", policy.ID, info.JobID)
+		return
+	}
+
+	utils.LogMed().Infof("Policy %s (%s) matched job %s for tenant %s", policy.ID, policy.Action, info.JobID, info.TenantID)
+
+	tenant := try.To1(l.db.GetAgent(&info.TenantID, nil))
+
+	l.resumes.Add(1)
+	go func() {
+		defer l.resumes.Done()
+		l.resumeByPolicy(l.BackgroundAuth(tenant), info, job, policy, target.ProtocolType)
+	}()
+}
+
+// resumeByPolicy resumes the job with the policy action, the job is left pending if the resume fails
+func (l *Listener) resumeByPolicy(
+	agent *agency.Agent,
+	info *agency.JobInfo,
+	job *dbModel.Job,
+	policy *dbModel.Policy,
+	protocolType model.ProtocolType,
+) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Warningf("Unable to resume job %s by policy %s, tenant %s: %s", info.JobID, policy.ID, info.TenantID, err)
+	}))
+
+	accept := policy.Action == model.PolicyActionAccept
+	description := "Credential offer"
+	if protocolType == model.ProtocolTypeCredential {
+		try.To(l.agency.ResumeCredentialOffer(context.Background(), agent, info, accept))
+	} else {
+		description = "Proof request"
+		try.To(l.agency.ResumeProofRequest(context.Background(), agent, info, accept))
+	}
+
+	action := "accepted"
+	if !accept {
+		action = "declined"
+	}
+	try.To(l.AddEvent(info.TenantID, job, fmt.Sprintf("%s %s automatically by policy %s", description, action, policy.ID)))
 }
 
-func (l *Listener) UpdateCredential(
+func (l *Listener) updateCredential(
 	info *agency.JobInfo,
 	credentialInput *agency.Credential,
 	data *agency.CredentialUpdate,
@@ -131,11 +270,14 @@ func (l *Listener) UpdateCredential(
 		if credentialInput != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
 			// we might have auto-accepting on in case we want to add the credential here
 			utils.LogHigh().Infof("Adding credential on update for tenant %s", info.TenantID)
-			job = try.To1(l.AddCredential(info, credentialInput))
+			job = try.To1(l.addCredential(info, credentialInput))
 		} else {
 			return err
 		}
 	}
+	if isClosed(job) {
+		return nil
+	}
 
 	utils.LogMed().Infof("Update credential %s for tenant %s", *job.ProtocolCredentialID, info.TenantID)
 
@@ -148,6 +290,7 @@ func (l *Listener) UpdateCredential(
 	credential = try.To1(l.db.UpdateCredential(credential))
 
 	job.Status, job.Result = getJobStatusForTimestamps(&credential.Approved, &credential.Issued, &credential.Failed)
+	setJobFailure(job, data.Failure)
 
 	try.To(l.UpdateJob(job, credential.Description()))
 
@@ -191,7 +334,7 @@ func (l *Listener) isProvable(info *agency.JobInfo, data *dbModel.Proof) bool {
 	return provable
 }
 
-func (l *Listener) AddProof(info *agency.JobInfo, data *agency.Proof) (job *dbModel.Job, err error) {
+func (l *Listener) addProof(info *agency.JobInfo, data *agency.Proof) (job *dbModel.Job, err error) {
 	defer err2.Handle(&err)
 
 	newProof := &dbModel.Proof{
@@ -199,6 +342,7 @@ func (l *Listener) AddProof(info *agency.JobInfo, data *agency.Proof) (job *dbMo
 		ConnectionID:  info.ConnectionID,
 		Role:          data.Role,
 		Attributes:    data.Attributes,
+		Predicates:    data.Predicates,
 		Result:        false,
 		InitiatedByUs: data.InitiatedByUs,
 	}
@@ -228,6 +372,20 @@ func (l *Listener) AddProof(info *agency.JobInfo, data *agency.Proof) (job *dbMo
 		Status:          status,
 		Result:          model.JobResultNone,
 	}, proof.Description()))
+
+	if newProof.Role == model.ProofRoleProver && !data.InitiatedByUs {
+		names := make([]string, len(proof.Attributes))
+		credDefIDs := make([]string, len(proof.Attributes))
+		for i, attr := range proof.Attributes {
+			names[i] = attr.Name
+			credDefIDs[i] = attr.CredDefID
+		}
+		l.applyPolicy(info, job, &dbModel.PolicyTarget{
+			ProtocolType: model.ProtocolTypeProof,
+			CredDefIDs:   credDefIDs,
+			Attributes:   names,
+		}, status != model.JobStatusBlocked)
+	}
 	return job, nil
 }
 
@@ -238,7 +396,7 @@ func (l *Listener) updateBlockedProof(job *dbModel.Job) (err error) {
 
 	proof := try.To1(l.db.GetProof(*job.ProtocolProofID, job.TenantID))
 
-	if l.isProvable(&agency.JobInfo{TenantID: job.TenantID, JobID: job.ID, ConnectionID: *job.ConnectionID}, proof) {
+	if l.isProvable(&agency.JobInfo{TenantID: job.TenantID, JobID: job.AgencyProtocolID(), ConnectionID: *job.ConnectionID}, proof) {
 		proof.Provable = utils.CurrentTime()
 		proof = try.To1(l.db.UpdateProof(proof))
 
@@ -255,26 +413,37 @@ func (l *Listener) updateBlockedProof(job *dbModel.Job) (err error) {
 func getValuesForVerifier(proof *dbModel.Proof, proofInput *agency.Proof) []*model.ProofValue {
 	values := make([]*model.ProofValue, 0)
 	// Update values with the ones received as verifier
-	if proof.Role == model.ProofRoleVerifier && len(proof.Values) == 0 && len(proofInput.Values) > 0 {
-		for _, attr := range proof.Attributes {
-			value := ""
-			for _, v := range proofInput.Values {
-				if v.CredDefID == attr.CredDefID && v.Name == attr.Name {
-					value = v.Value
-					break
-				}
-			}
+	if proof.Role == model.ProofRoleVerifier && len(proof.Values) == 0 && proofInput != nil && len(proofInput.Values) > 0 {
+		for i, attr := range proof.Attributes {
 			// match attribute id and value
 			values = append(values, &model.ProofValue{
 				AttributeID: attr.ID,
-				Value:       value,
+				Value:       verifiedValue(agency.AttributeReferent(i), attr, proofInput.Values),
 			})
 		}
 	}
 	return values
 }
 
-func (l *Listener) UpdateProof(info *agency.JobInfo, proofInput *agency.Proof, data *agency.ProofUpdate) (err error) {
+// verifiedValue picks the presented value for the requested attribute. Values are matched
+// with the attribute referent when the agency reports it, otherwise with the attribute name
+// and restrictions.
+func verifiedValue(referent string, attr *model.ProofAttribute, values []*agency.ProofValue) string {
+	for _, v := range values {
+		if v.ID != "" {
+			if v.ID == referent {
+				return v.Value
+			}
+			continue
+		}
+		if v.Name == attr.Name && dbModel.AttributeMatchesCredential(attr, "", v.CredDefID) {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+func (l *Listener) updateProof(info *agency.JobInfo, proofInput *agency.Proof, data *agency.ProofUpdate) (err error) {
 	defer err2.Handle(&err)
 
 	job, err := l.db.GetJob(info.JobID, info.TenantID)
@@ -282,11 +451,14 @@ func (l *Listener) UpdateProof(info *agency.JobInfo, proofInput *agency.Proof, d
 		if proofInput != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
 			// we might have auto-accepting on in case we want to add the proof here
 			utils.LogHigh().Infof("Adding proof on update for tenant %s", info.TenantID)
-			job = try.To1(l.AddProof(info, proofInput))
+			job = try.To1(l.addProof(info, proofInput))
 		} else {
 			return err
 		}
 	}
+	if isClosed(job) {
+		return nil
+	}
 
 	role := model.ProofRoleProver
 	if proofInput != nil {
@@ -305,7 +477,7 @@ func (l *Listener) UpdateProof(info *agency.JobInfo, proofInput *agency.Proof, d
 
 	proof.Approved = utils.TSToTimeIfNotSet(&proof.Approved, data.ApprovedMs)
 	proof.Verified = utils.TSToTimeIfNotSet(&proof.Verified, data.VerifiedMs)
-	proof.Failed = utils.TSToTimeIfNotSet(&proof.Verified, data.VerifiedMs)
+	proof.Failed = utils.TSToTimeIfNotSet(&proof.Failed, data.FailedMs)
 
 	if proof.Role == model.ProofRoleProver && !proof.Verified.IsZero() {
 		// TODO: these values should come from agency
@@ -326,11 +498,101 @@ func (l *Listener) UpdateProof(info *agency.JobInfo, proofInput *agency.Proof, d
 	proof = try.To1(l.db.UpdateProof(proof))
 
 	job.Status, job.Result = getJobStatusForProof(proof)
+	setJobFailure(job, data.Failure)
 
 	try.To(l.UpdateJob(job, proof.Description()))
 	return nil
 }
 
+func (l *Listener) addPing(info *agency.JobInfo, data *agency.Ping) (job *dbModel.Job, err error) {
+	defer err2.Handle(&err)
+
+	connection := try.To1(l.db.GetConnection(info.ConnectionID, info.TenantID))
+
+	utils.LogMed().Infof("Add trust ping for connection %s, tenant %s", connection.ID, info.TenantID)
+
+	status := model.JobStatusWaiting
+	description := "Sent trust ping to " + connection.TheirLabel
+	if !data.InitiatedByUs {
+		status = model.JobStatusPending
+		description = "Received trust ping from " + connection.TheirLabel
+	}
+
+	return l.AddJob(&dbModel.Job{
+		Base:                 dbModel.Base{ID: info.JobID, TenantID: info.TenantID},
+		ConnectionID:         &info.ConnectionID,
+		ProtocolType:         model.ProtocolTypeTrustPing,
+		ProtocolConnectionID: &connection.ID,
+		InitiatedByUs:        data.InitiatedByUs,
+		Status:               status,
+		Result:               model.JobResultNone,
+	}, description)
+}
+
+func (l *Listener) updatePing(info *agency.JobInfo, pingInput *agency.Ping, data *agency.PingUpdate) (err error) {
+	defer err2.Handle(&err)
+
+	job, err := l.db.GetJob(info.JobID, info.TenantID)
+	if err != nil {
+		if pingInput != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+			// other end initiated the ping, no job exists yet
+			utils.LogHigh().Infof("Adding trust ping on update for tenant %s", info.TenantID)
+			job = try.To1(l.addPing(info, pingInput))
+		} else {
+			return err
+		}
+	}
+	if isClosed(job) {
+		return nil
+	}
+
+	utils.LogMed().Infof("Update trust ping for connection %s, tenant %s", *job.ProtocolConnectionID, info.TenantID)
+
+	connection := try.To1(l.db.GetConnection(*job.ProtocolConnectionID, job.TenantID))
+
+	var replied, failed time.Time
+	replied = utils.TSToTimeIfNotSet(&replied, data.RepliedMs)
+	failed = utils.TSToTimeIfNotSet(&failed, data.FailedMs)
+
+	if !replied.IsZero() {
+		connection.LastSeen = replied
+	}
+	// only pings sent by us tell if the other end is reachable
+	if job.InitiatedByUs {
+		connection.LastPingResult = !replied.IsZero()
+		connection.LastPing = replied
+		if !failed.IsZero() {
+			connection.LastPing = failed
+		}
+	}
+
+	connection = try.To1(l.db.UpdateConnection(connection))
+
+	job.Status, job.Result = getJobStatusForTimestamps(nil, &replied, &failed)
+	setJobFailure(job, data.Failure)
+
+	description := "Trust ping to " + connection.TheirLabel + " succeeded"
+	if !job.InitiatedByUs {
+		description = "Replied to trust ping from " + connection.TheirLabel
+	} else if job.Result == model.JobResultFailure {
+		description = "Trust ping to " + connection.TheirLabel + " failed"
+	}
+
+	try.To(l.UpdateJob(job, description))
+	return nil
+}
+
+// isClosed reports if the job was cancelled or expired in the vault,
+// late notifications of the released protocol must not reopen the job
+func isClosed(job *dbModel.Job) bool {
+	if job.Result != model.JobResultCancelled && job.Result != model.JobResultExpired {
+		return false
+	}
+	utils.LogMed().Infof("Skip notification for job %s in result %s, tenant %s", job.ID, job.Result, job.TenantID)
+	skippedNotifications.Add(job.Result.String(), 1)
+	return true
+}
+
 func getJobStatusForTimestamps(approved, completed, failed *time.Time) (status model.JobStatus, result model.JobResult) {
 	status = model.JobStatusWaiting
 	result = model.JobResultNone
@@ -354,15 +616,176 @@ func getJobStatusForProof(proof *dbModel.Proof) (status model.JobStatus, result
 	return
 }
 
-func (l *Listener) FailJob(info *agency.JobInfo) (err error) {
+func setJobFailure(job *dbModel.Job, failure *agency.JobFailure) {
+	if failure != nil {
+		job.FailureCode = failure.Code
+		job.FailureReason = failure.Message
+	}
+}
+
+func (l *Listener) failJob(info *agency.JobInfo, failure *agency.JobFailure) (err error) {
 	defer err2.Handle(&err)
 
 	job := try.To1(l.db.GetJob(info.JobID, info.TenantID))
+	if isClosed(job) {
+		return nil
+	}
 
 	utils.LogMed().Infof("Fail job %s for tenant %s", job.ID, info.TenantID)
 	job.Status = model.JobStatusComplete
 	job.Result = model.JobResultFailure
+	setJobFailure(job, failure)
 
-	try.To(l.UpdateJob(job, fmt.Sprintf("Protocol %s failed", job.ProtocolType.String())))
+	description := fmt.Sprintf("Protocol %s failed", job.ProtocolType.String())
+	if job.FailureReason != "" {
+		description += ": " + job.FailureReason
+	}
+
+	try.To(l.UpdateJob(job, description))
 	return nil
 }
+
+// OpenJobs returns the uncompleted jobs of the tenant that have a protocol at agency,
+// jobs still queued in the outbox are left out
+func (l *Listener) OpenJobs(tenantID string) (jobs []*dbModel.Job, err error) {
+	defer err2.Handle(&err)
+
+	completed := false
+	after := uint64(0)
+	jobs = make([]*dbModel.Job, 0)
+	for nextPage := true; nextPage; {
+		batch, batchErr := l.db.GetJobs(&paginator.BatchInfo{Count: 50, After: after}, tenantID, nil, &completed)
+		if batchErr != nil && store.ErrorCode(batchErr) == store.ErrCodeNotFound {
+			break
+		}
+		try.To(batchErr)
+
+		count := len(batch.Jobs)
+		if count == 0 {
+			break
+		}
+		for _, job := range batch.Jobs {
+			if item := try.To1(outbox.UndeliveredItem(l.db, job)); item == nil {
+				jobs = append(jobs, job)
+			}
+		}
+		nextPage = batch.HasNextPage
+		after = batch.Jobs[count-1].Cursor
+	}
+	return jobs, nil
+}
+
+func (l *Listener) AddDeadLetter(letter *dbModel.DeadLetter) (err error) {
+	defer err2.Handle(&err)
+
+	letter = try.To1(l.db.AddDeadLetter(letter))
+	utils.LogMed().Infof("Added dead letter %s for job %s, tenant %s", letter.ID, letter.ProtocolID, letter.TenantID)
+	return nil
+}
+
+const expiredJobBatchSize = 100
+
+const expiredFailureCode = "EXPIRED"
+
+// ExpireJobs completes all jobs that have been waiting for the other party longer than the configured timeout.
+// Jobs whose protocol cannot be released at agency are failed, so that they are not retried on every sweep.
+func (l *Listener) ExpireJobs() (count int, err error) {
+	defer err2.Handle(&err)
+
+	for {
+		jobs := try.To1(l.db.GetExpiredJobs(utils.CurrentTime(), expiredJobBatchSize))
+		expired := 0
+		for _, job := range jobs {
+			if err := l.expireJob(job); err != nil {
+				glog.Errorf("Unable to expire job %s: %s", job.ID, err.Error())
+				continue
+			}
+			expired++
+		}
+		count += expired
+		// stop when all expired jobs are handled or none of the batch could be handled
+		if len(jobs) < expiredJobBatchSize || expired == 0 {
+			break
+		}
+	}
+	return count, nil
+}
+
+func (l *Listener) expireJob(job *dbModel.Job) (err error) {
+	defer err2.Handle(&err)
+
+	utils.LogMed().Infof("Expire job %s for tenant %s", job.ID, job.TenantID)
+
+	job.Result = model.JobResultExpired
+	job.FailureCode = expiredFailureCode
+	job.FailureReason = "no reply from the other party"
+	if releaseErr := l.releaseProtocol(job); releaseErr != nil {
+		glog.Warningf("Unable to release protocol of expired job %s: %s", job.ID, releaseErr.Error())
+		job.Result = model.JobResultFailure
+		job.FailureReason += ", protocol could not be released"
+	}
+
+	now := utils.CurrentTime()
+	if job.ProtocolCredentialID != nil {
+		credential := try.To1(l.db.GetCredential(*job.ProtocolCredentialID, job.TenantID))
+		credential.Failed = now
+		try.To1(l.db.UpdateCredential(credential))
+	}
+	if job.ProtocolProofID != nil {
+		proof := try.To1(l.db.GetProof(*job.ProtocolProofID, job.TenantID))
+		proof.Failed = now
+		try.To1(l.db.UpdateProof(proof))
+	}
+
+	job.Status = model.JobStatusComplete
+	try.To(l.UpdateJob(job, fmt.Sprintf("Protocol %s expired", job.ProtocolType.String())))
+	return nil
+}
+
+// releaseProtocol releases the agency protocol of the job so that late replies do not proceed it.
+// Jobs queued in the outbox are not delivered anymore and unused invitations have no protocol at agency.
+func (l *Listener) releaseProtocol(job *dbModel.Job) (err error) {
+	defer err2.Handle(&err)
+
+	if item := try.To1(outbox.UndeliveredItem(l.db, job)); item != nil {
+		return outbox.Cancel(l.db, item)
+	}
+	if job.IsInvitation() {
+		return nil
+	}
+
+	tenant := try.To1(l.db.GetAgent(&job.TenantID, nil))
+	info := &agency.JobInfo{TenantID: job.TenantID, JobID: job.AgencyProtocolID()}
+	if job.ConnectionID != nil {
+		info.ConnectionID = *job.ConnectionID
+	}
+	return l.agency.CancelJob(context.Background(), l.BackgroundAuth(tenant), info, job.ProtocolType)
+}
+
+// SweepExpiredJobs expires stale jobs periodically. When isLeader is set,
+// the jobs are expired only while this replica is the leader so that the replicas do not race for the same jobs.
+func (l *Listener) SweepExpiredJobs(ctx context.Context, interval time.Duration, isLeader func() bool) {
+	utils.LogMed().Infof("Start sweeping expired jobs every %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogMed().Infoln("Stop sweeping expired jobs")
+			return
+		case <-ticker.C:
+		}
+		if isLeader != nil && !isLeader() {
+			continue
+		}
+		count, err := l.ExpireJobs()
+		if err != nil {
+			glog.Errorf("Failure when expiring jobs: %s", err.Error())
+			continue
+		}
+		if count > 0 {
+			utils.LogMed().Infof("Expired %d jobs", count)
+		}
+	}
+}
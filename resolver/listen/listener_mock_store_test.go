@@ -5,9 +5,12 @@
 package listen
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/findy-network/findy-agent-vault/db/model"
+	store "github.com/findy-network/findy-agent-vault/db/store"
 	model0 "github.com/findy-network/findy-agent-vault/graph/model"
 	paginator "github.com/findy-network/findy-agent-vault/paginator"
 	gomock "github.com/golang/mock/gomock"
@@ -81,6 +84,21 @@ func (mr *MockDBMockRecorder) AddCredential(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCredential", reflect.TypeOf((*MockDB)(nil).AddCredential), c)
 }
 
+// AddDeadLetter mocks base method.
+func (m *MockDB) AddDeadLetter(d *model.DeadLetter) (*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetter", d)
+	ret0, _ := ret[0].(*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDeadLetter indicates an expected call of AddDeadLetter.
+func (mr *MockDBMockRecorder) AddDeadLetter(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetter", reflect.TypeOf((*MockDB)(nil).AddDeadLetter), d)
+}
+
 // AddEvent mocks base method.
 func (m *MockDB) AddEvent(e *model.Event) (*model.Event, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +144,36 @@ func (mr *MockDBMockRecorder) AddMessage(m interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessage", reflect.TypeOf((*MockDB)(nil).AddMessage), m)
 }
 
+// AddOutboxItem mocks base method.
+func (m *MockDB) AddOutboxItem(o *model.OutboxItem) (*model.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOutboxItem", o)
+	ret0, _ := ret[0].(*model.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOutboxItem indicates an expected call of AddOutboxItem.
+func (mr *MockDBMockRecorder) AddOutboxItem(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOutboxItem", reflect.TypeOf((*MockDB)(nil).AddOutboxItem), o)
+}
+
+// AddPolicy mocks base method.
+func (m *MockDB) AddPolicy(p *model.Policy) (*model.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPolicy", p)
+	ret0, _ := ret[0].(*model.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPolicy indicates an expected call of AddPolicy.
+func (mr *MockDBMockRecorder) AddPolicy(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicy", reflect.TypeOf((*MockDB)(nil).AddPolicy), p)
+}
+
 // AddProof mocks base method.
 func (m *MockDB) AddProof(p *model.Proof) (*model.Proof, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +245,66 @@ func (mr *MockDBMockRecorder) ArchiveProof(id, tenantID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProof", reflect.TypeOf((*MockDB)(nil).ArchiveProof), id, tenantID)
 }
 
+// ClaimDeadLetter mocks base method.
+func (m *MockDB) ClaimDeadLetter(id string, leaseUntil time.Time) (*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeadLetter", id, leaseUntil)
+	ret0, _ := ret[0].(*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeadLetter indicates an expected call of ClaimDeadLetter.
+func (mr *MockDBMockRecorder) ClaimDeadLetter(id, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeadLetter", reflect.TypeOf((*MockDB)(nil).ClaimDeadLetter), id, leaseUntil)
+}
+
+// ClaimDueDeadLetter mocks base method.
+func (m *MockDB) ClaimDueDeadLetter(before, leaseUntil time.Time, maxAttempts int) (*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeadLetter", before, leaseUntil, maxAttempts)
+	ret0, _ := ret[0].(*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeadLetter indicates an expected call of ClaimDueDeadLetter.
+func (mr *MockDBMockRecorder) ClaimDueDeadLetter(before, leaseUntil, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeadLetter", reflect.TypeOf((*MockDB)(nil).ClaimDueDeadLetter), before, leaseUntil, maxAttempts)
+}
+
+// ClaimDueOutboxItem mocks base method.
+func (m *MockDB) ClaimDueOutboxItem(before, leaseUntil time.Time) (*model.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueOutboxItem", before, leaseUntil)
+	ret0, _ := ret[0].(*model.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueOutboxItem indicates an expected call of ClaimDueOutboxItem.
+func (mr *MockDBMockRecorder) ClaimDueOutboxItem(before, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueOutboxItem", reflect.TypeOf((*MockDB)(nil).ClaimDueOutboxItem), before, leaseUntil)
+}
+
+// ClaimNotification mocks base method.
+func (m *MockDB) ClaimNotification(tenantID, protocolID, state string, leaseUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotification", tenantID, protocolID, state, leaseUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotification indicates an expected call of ClaimNotification.
+func (mr *MockDBMockRecorder) ClaimNotification(tenantID, protocolID, state, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotification", reflect.TypeOf((*MockDB)(nil).ClaimNotification), tenantID, protocolID, state, leaseUntil)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() {
 	m.ctrl.T.Helper()
@@ -209,6 +317,36 @@ func (mr *MockDBMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
 }
 
+// DeleteProcessedNotifications mocks base method.
+func (m *MockDB) DeleteProcessedNotifications(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedNotifications", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedNotifications indicates an expected call of DeleteProcessedNotifications.
+func (mr *MockDBMockRecorder) DeleteProcessedNotifications(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedNotifications", reflect.TypeOf((*MockDB)(nil).DeleteProcessedNotifications), before)
+}
+
+// GetAccessedAgents mocks base method.
+func (m *MockDB) GetAccessedAgents(since time.Time) ([]*model.Agent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessedAgents", since)
+	ret0, _ := ret[0].([]*model.Agent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessedAgents indicates an expected call of GetAccessedAgents.
+func (mr *MockDBMockRecorder) GetAccessedAgents(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessedAgents", reflect.TypeOf((*MockDB)(nil).GetAccessedAgents), since)
+}
+
 // GetAgent mocks base method.
 func (m *MockDB) GetAgent(id, agentID *string) (*model.Agent, error) {
 	m.ctrl.T.Helper()
@@ -389,6 +527,36 @@ func (mr *MockDBMockRecorder) GetCredentials(info, tenantID, connectionID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockDB)(nil).GetCredentials), info, tenantID, connectionID)
 }
 
+// GetDeadLetter mocks base method.
+func (m *MockDB) GetDeadLetter(id string) (*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", id)
+	ret0, _ := ret[0].(*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockDBMockRecorder) GetDeadLetter(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockDB)(nil).GetDeadLetter), id)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockDB) GetDeadLetters(tenantID *string) ([]*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", tenantID)
+	ret0, _ := ret[0].([]*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockDBMockRecorder) GetDeadLetters(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockDB)(nil).GetDeadLetters), tenantID)
+}
+
 // GetEvent mocks base method.
 func (m *MockDB) GetEvent(id, tenantID string) (*model.Event, error) {
 	m.ctrl.T.Helper()
@@ -434,6 +602,21 @@ func (mr *MockDBMockRecorder) GetEvents(info, tenantID, connectionID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockDB)(nil).GetEvents), info, tenantID, connectionID)
 }
 
+// GetExpiredJobs mocks base method.
+func (m *MockDB) GetExpiredJobs(before time.Time, count int) ([]*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredJobs", before, count)
+	ret0, _ := ret[0].([]*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredJobs indicates an expected call of GetExpiredJobs.
+func (mr *MockDBMockRecorder) GetExpiredJobs(before, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredJobs", reflect.TypeOf((*MockDB)(nil).GetExpiredJobs), before, count)
+}
+
 // GetJob mocks base method.
 func (m *MockDB) GetJob(id, tenantID string) (*model.Job, error) {
 	m.ctrl.T.Helper()
@@ -524,6 +707,21 @@ func (mr *MockDBMockRecorder) GetListenerAgents(info interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListenerAgents", reflect.TypeOf((*MockDB)(nil).GetListenerAgents), info)
 }
 
+// GetLockHolder mocks base method.
+func (m *MockDB) GetLockHolder(ctx context.Context, key int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockHolder", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockHolder indicates an expected call of GetLockHolder.
+func (mr *MockDBMockRecorder) GetLockHolder(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockHolder", reflect.TypeOf((*MockDB)(nil).GetLockHolder), ctx, key)
+}
+
 // GetMessage mocks base method.
 func (m *MockDB) GetMessage(id, tenantID string) (*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -584,6 +782,36 @@ func (mr *MockDBMockRecorder) GetOpenProofJobs(tenantID, proofAttributes interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenProofJobs", reflect.TypeOf((*MockDB)(nil).GetOpenProofJobs), tenantID, proofAttributes)
 }
 
+// GetOutboxItem mocks base method.
+func (m *MockDB) GetOutboxItem(id, tenantID string) (*model.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxItem", id, tenantID)
+	ret0, _ := ret[0].(*model.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxItem indicates an expected call of GetOutboxItem.
+func (mr *MockDBMockRecorder) GetOutboxItem(id, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxItem", reflect.TypeOf((*MockDB)(nil).GetOutboxItem), id, tenantID)
+}
+
+// GetPolicies mocks base method.
+func (m *MockDB) GetPolicies(tenantID string) ([]*model.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", tenantID)
+	ret0, _ := ret[0].([]*model.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockDBMockRecorder) GetPolicies(tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockDB)(nil).GetPolicies), tenantID)
+}
+
 // GetProof mocks base method.
 func (m *MockDB) GetProof(id, tenantID string) (*model.Proof, error) {
 	m.ctrl.T.Helper()
@@ -629,6 +857,51 @@ func (mr *MockDBMockRecorder) GetProofs(info, tenantID, connectionID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProofs", reflect.TypeOf((*MockDB)(nil).GetProofs), info, tenantID, connectionID)
 }
 
+// GetReplicas mocks base method.
+func (m *MockDB) GetReplicas(ctx context.Context, timeout time.Duration) ([]*model.Replica, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicas", ctx, timeout)
+	ret0, _ := ret[0].([]*model.Replica)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicas indicates an expected call of GetReplicas.
+func (mr *MockDBMockRecorder) GetReplicas(ctx, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicas", reflect.TypeOf((*MockDB)(nil).GetReplicas), ctx, timeout)
+}
+
+// LinkJob mocks base method.
+func (m *MockDB) LinkJob(id, protocolID, tenantID string) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkJob", id, protocolID, tenantID)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkJob indicates an expected call of LinkJob.
+func (mr *MockDBMockRecorder) LinkJob(id, protocolID, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkJob", reflect.TypeOf((*MockDB)(nil).LinkJob), id, protocolID, tenantID)
+}
+
+// ListenEvents mocks base method.
+func (m *MockDB) ListenEvents(ctx context.Context, pingInterval time.Duration) (<-chan *model.PublishedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenEvents", ctx, pingInterval)
+	ret0, _ := ret[0].(<-chan *model.PublishedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenEvents indicates an expected call of ListenEvents.
+func (mr *MockDBMockRecorder) ListenEvents(ctx, pingInterval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenEvents", reflect.TypeOf((*MockDB)(nil).ListenEvents), ctx, pingInterval)
+}
+
 // MarkEventRead mocks base method.
 func (m *MockDB) MarkEventRead(id, tenantID string) (*model.Event, error) {
 	m.ctrl.T.Helper()
@@ -644,6 +917,90 @@ func (mr *MockDBMockRecorder) MarkEventRead(id, tenantID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventRead", reflect.TypeOf((*MockDB)(nil).MarkEventRead), id, tenantID)
 }
 
+// MarkNotificationProcessed mocks base method.
+func (m *MockDB) MarkNotificationProcessed(tenantID, protocolID, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationProcessed", tenantID, protocolID, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationProcessed indicates an expected call of MarkNotificationProcessed.
+func (mr *MockDBMockRecorder) MarkNotificationProcessed(tenantID, protocolID, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationProcessed", reflect.TypeOf((*MockDB)(nil).MarkNotificationProcessed), tenantID, protocolID, state)
+}
+
+// PublishEvent mocks base method.
+func (m *MockDB) PublishEvent(ctx context.Context, event *model.PublishedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEvent indicates an expected call of PublishEvent.
+func (mr *MockDBMockRecorder) PublishEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockDB)(nil).PublishEvent), ctx, event)
+}
+
+// ReleaseNotification mocks base method.
+func (m *MockDB) ReleaseNotification(tenantID, protocolID, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseNotification", tenantID, protocolID, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseNotification indicates an expected call of ReleaseNotification.
+func (mr *MockDBMockRecorder) ReleaseNotification(tenantID, protocolID, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNotification", reflect.TypeOf((*MockDB)(nil).ReleaseNotification), tenantID, protocolID, state)
+}
+
+// RemoveDeadLetter mocks base method.
+func (m *MockDB) RemoveDeadLetter(id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDeadLetter", id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDeadLetter indicates an expected call of RemoveDeadLetter.
+func (mr *MockDBMockRecorder) RemoveDeadLetter(id, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeadLetter", reflect.TypeOf((*MockDB)(nil).RemoveDeadLetter), id, tenantID)
+}
+
+// RemovePolicy mocks base method.
+func (m *MockDB) RemovePolicy(id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePolicy", id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePolicy indicates an expected call of RemovePolicy.
+func (mr *MockDBMockRecorder) RemovePolicy(id, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePolicy", reflect.TypeOf((*MockDB)(nil).RemovePolicy), id, tenantID)
+}
+
+// RemoveReplica mocks base method.
+func (m *MockDB) RemoveReplica(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReplica", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReplica indicates an expected call of RemoveReplica.
+func (mr *MockDBMockRecorder) RemoveReplica(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReplica", reflect.TypeOf((*MockDB)(nil).RemoveReplica), ctx, id)
+}
+
 // SearchCredentials mocks base method.
 func (m *MockDB) SearchCredentials(tenantID string, proofAttributes []*model0.ProofAttribute) ([]*model0.ProvableAttribute, error) {
 	m.ctrl.T.Helper()
@@ -659,6 +1016,36 @@ func (mr *MockDBMockRecorder) SearchCredentials(tenantID, proofAttributes interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredentials", reflect.TypeOf((*MockDB)(nil).SearchCredentials), tenantID, proofAttributes)
 }
 
+// TryLock mocks base method.
+func (m *MockDB) TryLock(ctx context.Context, key int64, holder string) (store.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, key, holder)
+	ret0, _ := ret[0].(store.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockDBMockRecorder) TryLock(ctx, key, holder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockDB)(nil).TryLock), ctx, key, holder)
+}
+
+// UpdateConnection mocks base method.
+func (m *MockDB) UpdateConnection(c *model.Connection) (*model.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConnection", c)
+	ret0, _ := ret[0].(*model.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConnection indicates an expected call of UpdateConnection.
+func (mr *MockDBMockRecorder) UpdateConnection(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConnection", reflect.TypeOf((*MockDB)(nil).UpdateConnection), c)
+}
+
 // UpdateCredential mocks base method.
 func (m *MockDB) UpdateCredential(c *model.Credential) (*model.Credential, error) {
 	m.ctrl.T.Helper()
@@ -674,6 +1061,21 @@ func (mr *MockDBMockRecorder) UpdateCredential(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredential", reflect.TypeOf((*MockDB)(nil).UpdateCredential), c)
 }
 
+// UpdateDeadLetter mocks base method.
+func (m *MockDB) UpdateDeadLetter(d *model.DeadLetter) (*model.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeadLetter", d)
+	ret0, _ := ret[0].(*model.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeadLetter indicates an expected call of UpdateDeadLetter.
+func (mr *MockDBMockRecorder) UpdateDeadLetter(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeadLetter", reflect.TypeOf((*MockDB)(nil).UpdateDeadLetter), d)
+}
+
 // UpdateJob mocks base method.
 func (m *MockDB) UpdateJob(j *model.Job) (*model.Job, error) {
 	m.ctrl.T.Helper()
@@ -704,6 +1106,36 @@ func (mr *MockDBMockRecorder) UpdateMessage(m interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockDB)(nil).UpdateMessage), m)
 }
 
+// UpdateOutboxItem mocks base method.
+func (m *MockDB) UpdateOutboxItem(o *model.OutboxItem) (*model.OutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOutboxItem", o)
+	ret0, _ := ret[0].(*model.OutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOutboxItem indicates an expected call of UpdateOutboxItem.
+func (mr *MockDBMockRecorder) UpdateOutboxItem(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOutboxItem", reflect.TypeOf((*MockDB)(nil).UpdateOutboxItem), o)
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockDB) UpdatePolicy(p *model.Policy) (*model.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", p)
+	ret0, _ := ret[0].(*model.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockDBMockRecorder) UpdatePolicy(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockDB)(nil).UpdatePolicy), p)
+}
+
 // UpdateProof mocks base method.
 func (m *MockDB) UpdateProof(p *model.Proof) (*model.Proof, error) {
 	m.ctrl.T.Helper()
@@ -718,3 +1150,82 @@ func (mr *MockDBMockRecorder) UpdateProof(p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProof", reflect.TypeOf((*MockDB)(nil).UpdateProof), p)
 }
+
+// UpdateReplica mocks base method.
+func (m *MockDB) UpdateReplica(ctx context.Context, id, ring string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReplica", ctx, id, ring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReplica indicates an expected call of UpdateReplica.
+func (mr *MockDBMockRecorder) UpdateReplica(ctx, id, ring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReplica", reflect.TypeOf((*MockDB)(nil).UpdateReplica), ctx, id, ring)
+}
+
+// MockLock is a mock of Lock interface.
+type MockLock struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockMockRecorder
+}
+
+// MockLockMockRecorder is the mock recorder for MockLock.
+type MockLockMockRecorder struct {
+	mock *MockLock
+}
+
+// NewMockLock creates a new mock instance.
+func NewMockLock(ctrl *gomock.Controller) *MockLock {
+	mock := &MockLock{ctrl: ctrl}
+	mock.recorder = &MockLockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLock) EXPECT() *MockLockMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLock) Check(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLockMockRecorder) Check(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLock)(nil).Check), ctx)
+}
+
+// Lost mocks base method.
+func (m *MockLock) Lost() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lost")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Lost indicates an expected call of Lost.
+func (mr *MockLockMockRecorder) Lost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lost", reflect.TypeOf((*MockLock)(nil).Lost))
+}
+
+// Release mocks base method.
+func (m *MockLock) Release() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLockMockRecorder) Release() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLock)(nil).Release))
+}
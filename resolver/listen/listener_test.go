@@ -1,14 +1,18 @@
 package listen
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/findy-network/findy-agent-vault/agency/mock"
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	"github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/db/store"
 	graph "github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
 	"github.com/findy-network/findy-agent-vault/resolver/update"
 	"github.com/findy-network/findy-agent-vault/utils"
@@ -51,9 +55,21 @@ func TestMain(m *testing.M) {
 }
 
 func createListener(db store.DB) *Listener {
-	agentResolver := agent.NewResolver(db, nil)
-	updater := update.NewUpdater(db, agentResolver)
-	return &Listener{db, updater}
+	agentResolver := agent.NewResolver(db, nil, "")
+	updater := update.NewUpdater(db, agentResolver, "", nil)
+	return &Listener{db: db, Updater: updater}
+}
+
+func expectNewNotification(m *MockDB) {
+	m.
+		EXPECT().
+		ClaimNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(true, nil)
+	m.
+		EXPECT().
+		MarkNotificationProcessed(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
 }
 
 func TestAddConnection(t *testing.T) {
@@ -61,6 +77,7 @@ func TestAddConnection(t *testing.T) {
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 
 	var (
 		job        = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
@@ -120,6 +137,7 @@ func TestAddMessage(t *testing.T) {
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		job     = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
 		message = &agency.Message{
@@ -168,11 +186,91 @@ func TestAddMessage(t *testing.T) {
 	_ = l.AddMessage(job, message)
 }
 
+func TestUpdateMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	expectNewNotification(m)
+	var (
+		delivered     = true
+		messageID     = "message-id"
+		job           = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		messageUpdate = &agency.MessageUpdate{Delivered: delivered}
+		resultMessage = &model.Message{
+			Base:         model.Base{ID: messageID, TenantID: job.TenantID},
+			ConnectionID: job.ConnectionID,
+			Message:      "message",
+			SentByMe:     true,
+		}
+		updatedMessage = &model.Message{
+			Base:         model.Base{ID: messageID, TenantID: job.TenantID},
+			ConnectionID: job.ConnectionID,
+			Message:      "message",
+			SentByMe:     true,
+			Delivered:    &delivered,
+		}
+		resultJob = &model.Job{
+			Base:              model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID:      &job.ConnectionID,
+			ProtocolType:      graph.ProtocolTypeBasicMessage,
+			ProtocolMessageID: &messageID,
+			InitiatedByUs:     true,
+			Status:            graph.JobStatusWaiting,
+			Result:            graph.JobResultNone,
+		}
+		updatedJob = &model.Job{
+			Base:              model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID:      &job.ConnectionID,
+			ProtocolType:      graph.ProtocolTypeBasicMessage,
+			ProtocolMessageID: &messageID,
+			InitiatedByUs:     true,
+			Status:            graph.JobStatusComplete,
+			Result:            graph.JobResultSuccess,
+		}
+		event = &model.Event{
+			Base:         model.Base{TenantID: job.TenantID},
+			Read:         false,
+			Description:  updatedMessage.Description(),
+			ConnectionID: &job.ConnectionID,
+			JobID:        &job.JobID,
+		}
+	)
+
+	m.
+		EXPECT().
+		GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		GetMessage(gomock.Eq(messageID), gomock.Eq(job.TenantID)).
+		Return(resultMessage, nil)
+	m.
+		EXPECT().
+		UpdateMessage(updatedMessage).
+		Return(updatedMessage, nil)
+	m.
+		EXPECT().
+		UpdateJob(updatedJob).
+		Return(updatedJob, nil)
+	m.
+		EXPECT().
+		AddEvent(event).
+		Return(event, nil)
+
+	l := createListener(m)
+
+	if err := l.UpdateMessage(job, nil, messageUpdate); err != nil {
+		t.Errorf("Encountered error on update message %v", err)
+	}
+}
+
 func TestAddCredential(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		job              = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
 		resultCredential = &model.Credential{
@@ -215,16 +313,114 @@ func TestAddCredential(t *testing.T) {
 		AddEvent(event).
 		Return(event, nil)
 
+	m.
+		EXPECT().
+		GetPolicies(job.TenantID).
+		Return([]*model.Policy{}, nil)
+
 	l := createListener(m)
 
 	_, _ = l.AddCredential(job, credential)
 }
 
+func TestAddCredentialWithPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	expectNewNotification(m)
+	a := mock.NewMockAgency(ctrl)
+	var (
+		job              = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		resultCredential = &model.Credential{
+			Base:          model.Base{ID: "credential-id", TenantID: job.TenantID},
+			ConnectionID:  job.ConnectionID,
+			Role:          credential.Role,
+			SchemaID:      credential.SchemaID,
+			CredDefID:     credential.CredDefID,
+			Attributes:    credential.Attributes,
+			InitiatedByUs: credential.InitiatedByUs,
+		}
+		resultJob = &model.Job{
+			Base:                 model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID:         &job.ConnectionID,
+			ProtocolType:         graph.ProtocolTypeCredential,
+			ProtocolCredentialID: &resultCredential.ID,
+			Status:               graph.JobStatusPending,
+			Result:               graph.JobResultNone,
+		}
+		policies = []*model.Policy{
+			{
+				Base:         model.Base{ID: "other-policy-id"},
+				ProtocolType: graph.ProtocolTypeCredential,
+				Action:       graph.PolicyActionDecline,
+				TheirLabel:   "other",
+			},
+			{
+				Base:         model.Base{ID: "policy-id"},
+				ProtocolType: graph.ProtocolTypeCredential,
+				Action:       graph.PolicyActionAccept,
+				CredDefID:    credential.CredDefID,
+			},
+		}
+		event = &model.Event{
+			Base:         model.Base{TenantID: job.TenantID},
+			Description:  "Credential offer accepted automatically by policy policy-id",
+			ConnectionID: &job.ConnectionID,
+			JobID:        &job.JobID,
+		}
+	)
+
+	m.
+		EXPECT().
+		AddCredential(gomock.Any()).
+		Return(resultCredential, nil)
+	m.
+		EXPECT().
+		AddJob(gomock.Any()).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		AddEvent(gomock.Any()).
+		Return(&model.Event{}, nil)
+	m.
+		EXPECT().
+		GetPolicies(job.TenantID).
+		Return(policies, nil)
+	m.
+		EXPECT().
+		GetConnection(job.ConnectionID, job.TenantID).
+		Return(&model.Connection{Base: model.Base{ID: job.ConnectionID}, TheirLabel: "label"}, nil)
+	m.
+		EXPECT().
+		GetAgent(&job.TenantID, nil).
+		Return(&model.Agent{Base: model.Base{ID: job.TenantID}, AgentID: "agent-id"}, nil)
+	a.
+		EXPECT().
+		ResumeCredentialOffer(gomock.Any(), gomock.Any(), job, true).
+		Return(nil)
+	m.
+		EXPECT().
+		AddEvent(event).
+		Return(event, nil)
+
+	l := createListener(m)
+	l.agency = a
+
+	if _, err := l.AddCredential(job, credential); err != nil {
+		t.Errorf("Unexpected error when adding credential %s", err)
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Unexpected error when waiting for policy resume %s", err)
+	}
+}
+
 func TestUpdateCredential(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now              = utils.CurrentTimeMs()
 		credentialID     = "credential-id" //#nosec
@@ -287,6 +483,7 @@ func TestUpdateNonExistentCredential(t *testing.T) {
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now              = utils.CurrentTimeMs()
 		job              = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
@@ -375,6 +572,11 @@ func TestUpdateNonExistentCredential(t *testing.T) {
 		EXPECT().
 		GetOpenProofJobs(job.TenantID, []*graph.ProofAttribute{})
 
+	m.
+		EXPECT().
+		GetPolicies(job.TenantID).
+		Return([]*model.Policy{}, nil)
+
 	l := createListener(m)
 
 	_ = l.UpdateCredential(job, credential, credentialUpdate)
@@ -387,6 +589,7 @@ func TestAddProof(t *testing.T) {
 	utils.CurrentStaticTime = utils.CurrentTime()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now         = utils.CurrentTime()
 		job         = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
@@ -433,6 +636,11 @@ func TestAddProof(t *testing.T) {
 		AddEvent(event).
 		Return(event, nil)
 
+	m.
+		EXPECT().
+		GetPolicies(job.TenantID).
+		Return([]*model.Policy{}, nil)
+
 	l := createListener(m)
 
 	_, _ = l.AddProof(job, proof)
@@ -445,6 +653,7 @@ func TestUpdateProof(t *testing.T) {
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now         = utils.CurrentTimeMs()
 		proofID     = "proof-id"
@@ -498,11 +707,48 @@ func TestUpdateProof(t *testing.T) {
 	_ = l.UpdateProof(job, nil, proofUpdate)
 }
 
+func TestUpdateProofForClosedJob(t *testing.T) {
+	for _, result := range []graph.JobResult{graph.JobResultCancelled, graph.JobResultExpired} {
+		t.Run(result.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDB(ctrl)
+			expectNewNotification(m)
+			var (
+				now       = utils.CurrentTimeMs()
+				proofID   = "proof-id"
+				job       = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+				resultJob = &model.Job{
+					Base:            model.Base{ID: job.JobID, TenantID: job.TenantID},
+					ConnectionID:    &job.ConnectionID,
+					ProtocolProofID: &proofID,
+					Status:          graph.JobStatusComplete,
+					Result:          result,
+				}
+			)
+
+			// proof and job are left untouched
+			m.
+				EXPECT().
+				GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+				Return(resultJob, nil)
+
+			l := createListener(m)
+
+			if err := l.UpdateProof(job, nil, &agency.ProofUpdate{VerifiedMs: &now}); err != nil {
+				t.Errorf("Unexpected error when updating closed job %s", err)
+			}
+		})
+	}
+}
+
 func TestUpdateProofForVerifier(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now         = utils.CurrentTimeMs()
 		proofID     = "proof-id"
@@ -561,6 +807,50 @@ func TestUpdateProofForVerifier(t *testing.T) {
 	_ = l.UpdateProof(job, srcProof, proofUpdate)
 }
 
+func TestGetValuesForVerifier(t *testing.T) {
+	const credDefID = "issuer:3:CL:12:tag"
+	tests := []struct {
+		name   string
+		attr   *graph.ProofAttribute
+		values []*agency.ProofValue
+		exp    string
+	}{
+		{"cred def", &graph.ProofAttribute{Name: "name", CredDefID: credDefID}, []*agency.ProofValue{
+			{Name: "name", CredDefID: "other", Value: "other"},
+			{Name: "name", CredDefID: credDefID, Value: "value"},
+		}, "value"},
+		{"schema restriction", &graph.ProofAttribute{
+			Name:         "name",
+			Restrictions: []*graph.ProofRestriction{{SchemaID: "schema-id"}},
+		}, []*agency.ProofValue{{Name: "name", CredDefID: credDefID, Value: "value"}}, "value"},
+		{"issuer restriction", &graph.ProofAttribute{
+			Name:         "name",
+			Restrictions: []*graph.ProofRestriction{{IssuerDid: "issuer"}},
+		}, []*agency.ProofValue{
+			{Name: "name", CredDefID: "other:3:CL:12:tag", Value: "other"},
+			{Name: "name", CredDefID: credDefID, Value: "value"},
+		}, "value"},
+		{"referent", &graph.ProofAttribute{Name: "name"}, []*agency.ProofValue{
+			{ID: "attribute_1", Name: "name", CredDefID: credDefID, Value: "other"},
+			{ID: agency.AttributeReferent(0), Name: "name", CredDefID: credDefID, Value: "value"},
+		}, "value"},
+		{"no match", &graph.ProofAttribute{Name: "name"}, []*agency.ProofValue{{Name: "other", Value: "other"}}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			proof := &model.Proof{
+				Role:       graph.ProofRoleVerifier,
+				Attributes: []*graph.ProofAttribute{tc.attr},
+			}
+			values := getValuesForVerifier(proof, &agency.Proof{Values: tc.values})
+			if len(values) != 1 || values[0].Value != tc.exp {
+				t.Errorf("Mismatch for verifier values, expected %s got %v", tc.exp, values)
+			}
+		})
+	}
+}
+
 func TestUpdateNonExistentProof(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -568,6 +858,7 @@ func TestUpdateNonExistentProof(t *testing.T) {
 	utils.CurrentStaticTime = utils.CurrentTime()
 
 	m := NewMockDB(ctrl)
+	expectNewNotification(m)
 	var (
 		now         = utils.CurrentTime()
 		nowMs       = now.UTC().UnixNano() / int64(time.Millisecond)
@@ -656,7 +947,412 @@ func TestUpdateNonExistentProof(t *testing.T) {
 		AddEvent(updateEvent).
 		Return(updateEvent, nil)
 
+	m.
+		EXPECT().
+		GetPolicies(job.TenantID).
+		Return([]*model.Policy{}, nil)
+
 	l := createListener(m)
 
 	_ = l.UpdateProof(job, proof, proofUpdate)
 }
+
+func TestFailJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	expectNewNotification(m)
+	var (
+		job       = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		failure   = &agency.JobFailure{Code: "ERR", Message: "failure reason"}
+		resultJob = &model.Job{
+			Base:         model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID: &job.ConnectionID,
+			ProtocolType: graph.ProtocolTypeBasicMessage,
+		}
+		failedJob = &model.Job{
+			Base:          model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID:  &job.ConnectionID,
+			ProtocolType:  graph.ProtocolTypeBasicMessage,
+			Status:        graph.JobStatusComplete,
+			Result:        graph.JobResultFailure,
+			FailureCode:   failure.Code,
+			FailureReason: failure.Message,
+		}
+		event = &model.Event{
+			Base:         model.Base{TenantID: job.TenantID},
+			Read:         false,
+			Description:  "Protocol BASIC_MESSAGE failed: failure reason",
+			ConnectionID: &job.ConnectionID,
+			JobID:        &job.JobID,
+		}
+	)
+
+	m.
+		EXPECT().
+		GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		UpdateJob(failedJob).
+		Return(failedJob, nil)
+	m.
+		EXPECT().
+		AddEvent(event).
+		Return(event, nil)
+
+	l := createListener(m)
+
+	if err := l.FailJob(job, failure); err != nil {
+		t.Errorf("Encountered error on fail job %v", err)
+	}
+}
+
+func TestUpdatePing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	expectNewNotification(m)
+	var (
+		now        = utils.CurrentTimeMs()
+		job        = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		pingUpdate = &agency.PingUpdate{RepliedMs: &now}
+		resultJob  = &model.Job{
+			Base:                 model.Base{ID: job.JobID, TenantID: job.TenantID},
+			ConnectionID:         &job.ConnectionID,
+			ProtocolConnectionID: &job.ConnectionID,
+			ProtocolType:         graph.ProtocolTypeTrustPing,
+			InitiatedByUs:        true,
+		}
+		connection = &model.Connection{
+			Base:       model.Base{ID: job.ConnectionID, TenantID: job.TenantID},
+			TheirLabel: "theirLabel",
+		}
+		updatedConnection = &model.Connection{
+			Base:           model.Base{ID: job.ConnectionID, TenantID: job.TenantID},
+			TheirLabel:     "theirLabel",
+			LastSeen:       utils.TSToTimeIfNotSet(&time.Time{}, &now),
+			LastPing:       utils.TSToTimeIfNotSet(&time.Time{}, &now),
+			LastPingResult: true,
+		}
+		event = &model.Event{
+			Base:         model.Base{TenantID: job.TenantID},
+			Read:         false,
+			Description:  "Trust ping to theirLabel succeeded",
+			ConnectionID: &job.ConnectionID,
+			JobID:        &job.JobID,
+		}
+	)
+
+	m.
+		EXPECT().
+		GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		GetConnection(gomock.Eq(job.ConnectionID), gomock.Eq(job.TenantID)).
+		Return(connection, nil)
+	m.
+		EXPECT().
+		UpdateConnection(updatedConnection).
+		Return(updatedConnection, nil)
+	m.
+		EXPECT().
+		UpdateJob(resultJob).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		AddEvent(event).
+		Return(event, nil)
+
+	l := createListener(m)
+
+	if err := l.UpdatePing(job, nil, pingUpdate); err != nil {
+		t.Errorf("Encountered error on update ping %v", err)
+	}
+	if resultJob.Status != graph.JobStatusComplete || resultJob.Result != graph.JobResultSuccess {
+		t.Errorf("Unexpected job state %s %s", resultJob.Status, resultJob.Result)
+	}
+}
+
+func TestOpenJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	var (
+		tenantID  = "tenant-id"
+		completed = false
+		firstJob  = &model.Job{Base: model.Base{ID: "first-job", TenantID: tenantID, Cursor: 1}}
+		secondJob = &model.Job{Base: model.Base{ID: "second-job", TenantID: tenantID, Cursor: 2}}
+		outboxJob = func(id string) *model.Job {
+			return &model.Job{
+				Base:          model.Base{ID: id, TenantID: tenantID, Cursor: 3},
+				ProtocolType:  graph.ProtocolTypeProof,
+				InitiatedByUs: true,
+			}
+		}
+		queuedJob    = outboxJob("queued-job")
+		deliveredJob = outboxJob("delivered-job")
+	)
+
+	m.
+		EXPECT().
+		GetJobs(gomock.Eq(&paginator.BatchInfo{Count: 50}), gomock.Eq(tenantID), gomock.Nil(), gomock.Eq(&completed)).
+		Return(&model.Jobs{Jobs: []*model.Job{firstJob}, HasNextPage: true}, nil)
+	m.
+		EXPECT().
+		GetJobs(gomock.Eq(&paginator.BatchInfo{Count: 50, After: firstJob.Cursor}), gomock.Eq(tenantID), gomock.Nil(), gomock.Eq(&completed)).
+		Return(&model.Jobs{Jobs: []*model.Job{secondJob, queuedJob, deliveredJob}, HasNextPage: false}, nil)
+	m.
+		EXPECT().
+		GetOutboxItem(queuedJob.ID, tenantID).
+		Return(&model.OutboxItem{Base: model.Base{ID: queuedJob.ID, TenantID: tenantID}}, nil)
+	m.
+		EXPECT().
+		GetOutboxItem(deliveredJob.ID, tenantID).
+		Return(&model.OutboxItem{Base: model.Base{ID: deliveredJob.ID, TenantID: tenantID}, Delivered: time.Now()}, nil)
+
+	l := createListener(m)
+
+	// job queued in the outbox has no protocol to reconcile
+	jobs, err := l.OpenJobs(tenantID)
+	if err != nil {
+		t.Errorf("Encountered error on open jobs %v", err)
+	}
+	if len(jobs) != 3 || jobs[0] != firstJob || jobs[1] != secondJob || jobs[2] != deliveredJob {
+		t.Errorf("Mismatch in open jobs, got: %+v", jobs)
+	}
+}
+
+func TestExpireJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	a := mock.NewMockAgency(ctrl)
+	var (
+		connectionID = "connection-id"
+		proofID      = "proof-id"
+		expiredJob   = &model.Job{
+			Base:            model.Base{ID: "job-id", TenantID: "tenant-id"},
+			ConnectionID:    &connectionID,
+			ProtocolType:    graph.ProtocolTypeProof,
+			ProtocolProofID: &proofID,
+			Status:          graph.JobStatusWaiting,
+		}
+		resultJob = &model.Job{
+			Base:            model.Base{ID: "job-id", TenantID: "tenant-id"},
+			ConnectionID:    &connectionID,
+			ProtocolType:    graph.ProtocolTypeProof,
+			ProtocolProofID: &proofID,
+			Status:          graph.JobStatusComplete,
+			Result:          graph.JobResultExpired,
+			FailureCode:     "EXPIRED",
+			FailureReason:   "no reply from the other party",
+		}
+		info  = &agency.JobInfo{TenantID: "tenant-id", JobID: "job-id", ConnectionID: connectionID}
+		event = &model.Event{
+			Base:         model.Base{TenantID: "tenant-id"},
+			Read:         false,
+			Description:  "Protocol PROOF expired",
+			ConnectionID: &connectionID,
+			JobID:        &expiredJob.ID,
+		}
+	)
+
+	m.
+		EXPECT().
+		GetExpiredJobs(gomock.Any(), gomock.Eq(expiredJobBatchSize)).
+		Return([]*model.Job{expiredJob}, nil)
+	m.
+		EXPECT().
+		GetAgent(&expiredJob.TenantID, nil).
+		Return(&model.Agent{Base: model.Base{ID: expiredJob.TenantID}, AgentID: "agent-id"}, nil)
+	a.
+		EXPECT().
+		CancelJob(gomock.Any(), gomock.Any(), info, graph.ProtocolTypeProof).
+		Return(nil)
+	m.
+		EXPECT().
+		GetProof(proofID, expiredJob.TenantID).
+		Return(&model.Proof{Base: model.Base{ID: proofID, TenantID: expiredJob.TenantID}}, nil)
+	m.
+		EXPECT().
+		UpdateProof(gomock.Any()).
+		DoAndReturn(func(proof *model.Proof) (*model.Proof, error) {
+			if proof.Failed.IsZero() {
+				t.Errorf("Expired proof was not marked failed")
+			}
+			return proof, nil
+		})
+	m.
+		EXPECT().
+		UpdateJob(resultJob).
+		Return(resultJob, nil)
+	m.
+		EXPECT().
+		AddEvent(event).
+		Return(event, nil)
+
+	l := createListener(m)
+	l.agency = a
+
+	count, err := l.ExpireJobs()
+	if err != nil {
+		t.Errorf("Encountered error on expire jobs %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Mismatch in expired job count, expected: %d got: %d", 1, count)
+	}
+}
+
+func TestExpireJobsReleaseFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	a := mock.NewMockAgency(ctrl)
+	expiredJob := &model.Job{
+		Base:         model.Base{ID: "job-id", TenantID: "tenant-id"},
+		ProtocolType: graph.ProtocolTypeCredential,
+		Status:       graph.JobStatusWaiting,
+	}
+
+	m.
+		EXPECT().
+		GetExpiredJobs(gomock.Any(), gomock.Eq(expiredJobBatchSize)).
+		Return([]*model.Job{expiredJob}, nil)
+	m.
+		EXPECT().
+		GetAgent(&expiredJob.TenantID, nil).
+		Return(&model.Agent{Base: model.Base{ID: expiredJob.TenantID}, AgentID: "agent-id"}, nil)
+	a.
+		EXPECT().
+		CancelJob(gomock.Any(), gomock.Any(), gomock.Any(), graph.ProtocolTypeCredential).
+		Return(errors.New("agency unavailable"))
+	// job is failed instead of retrying the release on every sweep
+	m.
+		EXPECT().
+		UpdateJob(gomock.Any()).
+		DoAndReturn(func(job *model.Job) (*model.Job, error) {
+			if job.Status != graph.JobStatusComplete || job.Result != graph.JobResultFailure || job.FailureCode != expiredFailureCode {
+				t.Errorf("Job not failed when release fails: %+v", job)
+			}
+			return job, nil
+		})
+	m.
+		EXPECT().
+		AddEvent(gomock.Any()).
+		Return(&model.Event{}, nil)
+
+	l := createListener(m)
+	l.agency = a
+
+	count, err := l.ExpireJobs()
+	if err != nil {
+		t.Errorf("Encountered error on expire jobs %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Mismatch in expired job count, expected: %d got: %d", 1, count)
+	}
+}
+
+func TestExpireJobsWithoutProtocol(t *testing.T) {
+	protocolID := "protocol-id"
+	tests := []struct {
+		name    string
+		job     *model.Job
+		queued  bool
+		release *agency.JobInfo
+	}{
+		{
+			"invitation",
+			&model.Job{ProtocolType: graph.ProtocolTypeConnection, InitiatedByUs: true},
+			false,
+			nil,
+		},
+		{
+			"connect",
+			&model.Job{ProtocolType: graph.ProtocolTypeConnection},
+			false,
+			&agency.JobInfo{TenantID: "tenant-id", JobID: "job-id"},
+		},
+		{
+			"queued outbox",
+			&model.Job{ProtocolType: graph.ProtocolTypeProof, InitiatedByUs: true},
+			true,
+			nil,
+		},
+		{
+			"delivered outbox",
+			&model.Job{ProtocolType: graph.ProtocolTypeProof, InitiatedByUs: true, ProtocolID: &protocolID},
+			false,
+			&agency.JobInfo{TenantID: "tenant-id", JobID: protocolID},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDB(ctrl)
+			a := mock.NewMockAgency(ctrl)
+			expiredJob := tc.job
+			expiredJob.Base = model.Base{ID: "job-id", TenantID: "tenant-id"}
+			expiredJob.Status = graph.JobStatusWaiting
+
+			m.
+				EXPECT().
+				GetExpiredJobs(gomock.Any(), gomock.Eq(expiredJobBatchSize)).
+				Return([]*model.Job{expiredJob}, nil)
+			if tc.queued {
+				m.
+					EXPECT().
+					GetOutboxItem(expiredJob.ID, expiredJob.TenantID).
+					Return(&model.OutboxItem{Base: expiredJob.Base}, nil)
+				m.
+					EXPECT().
+					UpdateOutboxItem(gomock.Any()).
+					DoAndReturn(func(item *model.OutboxItem) (*model.OutboxItem, error) {
+						if item.IsPending() {
+							t.Errorf("Outbox item of expired job is still pending")
+						}
+						return item, nil
+					})
+			}
+			if tc.release != nil {
+				m.
+					EXPECT().
+					GetAgent(&expiredJob.TenantID, nil).
+					Return(&model.Agent{Base: model.Base{ID: expiredJob.TenantID}, AgentID: "agent-id"}, nil)
+				a.
+					EXPECT().
+					CancelJob(gomock.Any(), gomock.Any(), tc.release, expiredJob.ProtocolType).
+					Return(nil)
+			}
+			m.
+				EXPECT().
+				UpdateJob(gomock.Any()).
+				DoAndReturn(func(job *model.Job) (*model.Job, error) {
+					if job.Result != graph.JobResultExpired {
+						t.Errorf("Job not expired: %+v", job)
+					}
+					return job, nil
+				})
+			m.
+				EXPECT().
+				AddEvent(gomock.Any()).
+				Return(&model.Event{}, nil)
+
+			l := createListener(m)
+			l.agency = a
+
+			if count, err := l.ExpireJobs(); err != nil || count != 1 {
+				t.Errorf("Mismatch in expired jobs, expected: 1 got: %d %v", count, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package listen
+
+import (
+	"context"
+	"expvar"
+	"strings"
+	"time"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// Agency may deliver the same notification several times e.g. when the listener reconnects.
+// Each handler claims the (tenant, protocol ID, state) before processing and records it processed
+// only after the handler has succeeded, so the replays are skipped. If the vault dies while handling,
+// the claim lease passes and the state can be processed again when the notification is replayed.
+// The processed states are kept for the configured retention.
+
+const (
+	stateConnection = "connection"
+	stateMessage    = "message"
+	stateCredential = "credential"
+	stateProof      = "proof"
+	statePing       = "ping"
+	stateFailed     = "failed"
+)
+
+const (
+	// claimLease must exceed the time it takes to handle a notification
+	claimLease = 5 * time.Minute
+	// CleanupInterval is the interval for removing the expired notification states
+	CleanupInterval = time.Hour
+)
+
+var skippedNotifications = expvar.NewMap("listener_skipped_notifications")
+
+func updateState(base string, values map[string]bool) string {
+	state := []string{base}
+	for _, key := range []string{"approved", "delivered", "issued", "verified", "replied", "failed"} {
+		if values[key] {
+			state = append(state, key)
+		}
+	}
+	return strings.Join(state, ":")
+}
+
+func messageUpdateState(update *agency.MessageUpdate) string {
+	return updateState(stateMessage, map[string]bool{
+		"delivered": update.Delivered,
+		"failed":    !update.Delivered,
+	})
+}
+
+func credentialUpdateState(update *agency.CredentialUpdate) string {
+	return updateState(stateCredential, map[string]bool{
+		"approved": update.ApprovedMs != nil,
+		"issued":   update.IssuedMs != nil,
+		"failed":   update.FailedMs != nil,
+	})
+}
+
+func proofUpdateState(update *agency.ProofUpdate) string {
+	return updateState(stateProof, map[string]bool{
+		"approved": update.ApprovedMs != nil,
+		"verified": update.VerifiedMs != nil,
+		"failed":   update.FailedMs != nil,
+	})
+}
+
+func pingUpdateState(update *agency.PingUpdate) string {
+	return updateState(statePing, map[string]bool{
+		"replied": update.RepliedMs != nil,
+		"failed":  update.FailedMs != nil,
+	})
+}
+
+// once runs the handler only if the notification state has not been processed yet.
+// The claim is released if the handler fails so that the notification can be retried.
+func (l *Listener) once(info *agency.JobInfo, state string, handler func() error) (processed bool, err error) {
+	defer err2.Handle(&err)
+
+	leaseUntil := time.Now().UTC().Add(claimLease)
+	if !try.To1(l.db.ClaimNotification(info.TenantID, info.JobID, state, leaseUntil)) {
+		utils.LogMed().Infof("Skip duplicate notification %s for job %s, tenant %s", state, info.JobID, info.TenantID)
+		skippedNotifications.Add(state, 1)
+		return false, nil
+	}
+
+	if err = handler(); err != nil {
+		if releaseErr := l.db.ReleaseNotification(info.TenantID, info.JobID, state); releaseErr != nil {
+			glog.Errorf("Unable to release notification state %s for job %s: %s", state, info.JobID, releaseErr)
+		}
+		return false, err
+	}
+
+	// handler has succeeded, the unmarked claim only lets a replay through after the lease
+	if markErr := l.db.MarkNotificationProcessed(info.TenantID, info.JobID, state); markErr != nil {
+		glog.Errorf("Unable to mark notification state %s processed for job %s: %s", state, info.JobID, markErr)
+	}
+	return true, nil
+}
+
+func (l *Listener) onceJob(
+	info *agency.JobInfo,
+	state string,
+	handler func() (*dbModel.Job, error),
+) (job *dbModel.Job, err error) {
+	defer err2.Handle(&err)
+
+	processed := try.To1(l.once(info, state, func() (err error) {
+		job, err = handler()
+		return err
+	}))
+	if !processed {
+		return l.db.GetJob(info.JobID, info.TenantID)
+	}
+	return job, nil
+}
+
+func (l *Listener) AddConnection(info *agency.JobInfo, data *agency.Connection) (err error) {
+	_, err = l.once(info, stateConnection, func() error {
+		return l.addConnection(info, data)
+	})
+	return err
+}
+
+func (l *Listener) AddMessage(info *agency.JobInfo, data *agency.Message) (err error) {
+	_, err = l.once(info, stateMessage, func() (err error) {
+		_, err = l.addMessage(info, data)
+		return err
+	})
+	return err
+}
+
+func (l *Listener) UpdateMessage(info *agency.JobInfo, messageInput *agency.Message, data *agency.MessageUpdate) (err error) {
+	_, err = l.once(info, messageUpdateState(data), func() error {
+		return l.updateMessage(info, messageInput, data)
+	})
+	return err
+}
+
+func (l *Listener) AddCredential(info *agency.JobInfo, data *agency.Credential) (*dbModel.Job, error) {
+	return l.onceJob(info, stateCredential, func() (*dbModel.Job, error) {
+		return l.addCredential(info, data)
+	})
+}
+
+func (l *Listener) UpdateCredential(
+	info *agency.JobInfo,
+	credentialInput *agency.Credential,
+	data *agency.CredentialUpdate,
+) (err error) {
+	_, err = l.once(info, credentialUpdateState(data), func() error {
+		return l.updateCredential(info, credentialInput, data)
+	})
+	return err
+}
+
+func (l *Listener) AddProof(info *agency.JobInfo, data *agency.Proof) (*dbModel.Job, error) {
+	return l.onceJob(info, stateProof, func() (*dbModel.Job, error) {
+		return l.addProof(info, data)
+	})
+}
+
+func (l *Listener) UpdateProof(info *agency.JobInfo, proofInput *agency.Proof, data *agency.ProofUpdate) (err error) {
+	_, err = l.once(info, proofUpdateState(data), func() error {
+		return l.updateProof(info, proofInput, data)
+	})
+	return err
+}
+
+func (l *Listener) AddPing(info *agency.JobInfo, data *agency.Ping) (*dbModel.Job, error) {
+	return l.onceJob(info, statePing, func() (*dbModel.Job, error) {
+		return l.addPing(info, data)
+	})
+}
+
+func (l *Listener) UpdatePing(info *agency.JobInfo, pingInput *agency.Ping, data *agency.PingUpdate) (err error) {
+	_, err = l.once(info, pingUpdateState(data), func() error {
+		return l.updatePing(info, pingInput, data)
+	})
+	return err
+}
+
+func (l *Listener) FailJob(info *agency.JobInfo, failure *agency.JobFailure) (err error) {
+	_, err = l.once(info, stateFailed, func() error {
+		return l.failJob(info, failure)
+	})
+	return err
+}
+
+// CleanProcessedNotifications removes periodically the notification states processed earlier than the retention
+func (l *Listener) CleanProcessedNotifications(ctx context.Context, interval, retention time.Duration) {
+	utils.LogMed().Infof("Start cleaning processed notifications older than %v every %v", retention, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogMed().Infoln("Stop cleaning processed notifications")
+			return
+		case <-ticker.C:
+		}
+		count, err := l.db.DeleteProcessedNotifications(time.Now().UTC().Add(-retention))
+		if err != nil {
+			glog.Errorf("Failure when cleaning processed notifications: %s", err.Error())
+			continue
+		}
+		if count > 0 {
+			utils.LogMed().Infof("Removed %d processed notifications", count)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package listen
+
+import (
+	"errors"
+	"testing"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/db/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestUpdateStates(t *testing.T) {
+	now := int64(1)
+	tests := []struct {
+		name  string
+		state string
+		exp   string
+	}{
+		{"message delivered", messageUpdateState(&agency.MessageUpdate{Delivered: true}), "message:delivered"},
+		{"message failed", messageUpdateState(&agency.MessageUpdate{Delivered: false}), "message:failed"},
+		{"credential approved", credentialUpdateState(&agency.CredentialUpdate{ApprovedMs: &now}), "credential:approved"},
+		{"credential issued", credentialUpdateState(&agency.CredentialUpdate{IssuedMs: &now}), "credential:issued"},
+		{"proof verified", proofUpdateState(&agency.ProofUpdate{VerifiedMs: &now}), "proof:verified"},
+		{"proof failed", proofUpdateState(&agency.ProofUpdate{FailedMs: &now}), "proof:failed"},
+		{"ping replied", pingUpdateState(&agency.PingUpdate{RepliedMs: &now}), "ping:replied"},
+	}
+	for _, tc := range tests {
+		if tc.state != tc.exp {
+			t.Errorf("Mismatch in state for %s, expected: %s got: %s", tc.name, tc.exp, tc.state)
+		}
+	}
+}
+
+func TestAddMessageDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	job := &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+
+	m.
+		EXPECT().
+		ClaimNotification(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateMessage), gomock.Any()).
+		Return(false, nil)
+
+	l := createListener(m)
+
+	before := skippedCount(stateMessage)
+	if err := l.AddMessage(job, &agency.Message{Message: "message"}); err != nil {
+		t.Errorf("Encountered error on add message %v", err)
+	}
+	if skippedCount(stateMessage) != before+1 {
+		t.Errorf("Skipped duplicate was not counted")
+	}
+}
+
+func TestAddCredentialDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	var (
+		job         = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		existingJob = &model.Job{Base: model.Base{ID: job.JobID, TenantID: job.TenantID}}
+	)
+
+	m.
+		EXPECT().
+		ClaimNotification(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateCredential), gomock.Any()).
+		Return(false, nil)
+	m.
+		EXPECT().
+		GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+		Return(existingJob, nil)
+
+	l := createListener(m)
+
+	got, err := l.AddCredential(job, credential)
+	if err != nil {
+		t.Errorf("Encountered error on add credential %v", err)
+	}
+	if got != existingJob {
+		t.Errorf("Expected existing job, got %+v", got)
+	}
+}
+
+func TestFailJobReleasesStateOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	job := &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+
+	m.
+		EXPECT().
+		ClaimNotification(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateFailed), gomock.Any()).
+		Return(true, nil)
+	m.
+		EXPECT().
+		GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+		Return(nil, errors.New("db failure"))
+	m.
+		EXPECT().
+		ReleaseNotification(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateFailed)).
+		Return(nil)
+
+	l := createListener(m)
+
+	if err := l.FailJob(job, &agency.JobFailure{Code: "ERR"}); err == nil {
+		t.Errorf("Expected error on fail job")
+	}
+}
+
+func TestFailJobMarksStateProcessed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDB(ctrl)
+	var (
+		job       = &agency.JobInfo{JobID: "job-id", TenantID: "tenant-id", ConnectionID: "connection-id"}
+		resultJob = &model.Job{Base: model.Base{ID: job.JobID, TenantID: job.TenantID}, ConnectionID: &job.ConnectionID}
+	)
+
+	gomock.InOrder(
+		m.
+			EXPECT().
+			ClaimNotification(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateFailed), gomock.Any()).
+			Return(true, nil),
+		m.
+			EXPECT().
+			GetJob(gomock.Eq(job.JobID), gomock.Eq(job.TenantID)).
+			Return(resultJob, nil),
+		m.
+			EXPECT().
+			UpdateJob(gomock.Any()).
+			Return(resultJob, nil),
+		m.
+			EXPECT().
+			AddEvent(gomock.Any()).
+			Return(&model.Event{}, nil),
+		// state is recorded only after the handler has succeeded
+		m.
+			EXPECT().
+			MarkNotificationProcessed(gomock.Eq(job.TenantID), gomock.Eq(job.JobID), gomock.Eq(stateFailed)).
+			Return(nil),
+	)
+
+	l := createListener(m)
+
+	if err := l.FailJob(job, &agency.JobFailure{Code: "ERR"}); err != nil {
+		t.Errorf("Unexpected error on fail job %s", err)
+	}
+}
+
+func skippedCount(state string) int64 {
+	if v := skippedNotifications.Get(state); v != nil {
+		return v.(interface{ Value() int64 }).Value()
+	}
+	return 0
+}
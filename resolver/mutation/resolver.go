@@ -2,12 +2,15 @@ package mutation
 
 import (
 	"context"
+	"fmt"
 
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/resolver/deadletter"
 	"github.com/findy-network/findy-agent-vault/resolver/invitation"
+	"github.com/findy-network/findy-agent-vault/resolver/outbox"
 	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
 	"github.com/findy-network/findy-agent-vault/resolver/update"
 	"github.com/findy-network/findy-agent-vault/utils"
@@ -15,9 +18,37 @@ import (
 	"github.com/lainio/err2/try"
 )
 
+var predicateOperators = map[string]bool{">=": true, ">": true, "<=": true, "<": true}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func toProofRestrictions(input []*model.ProofRestrictionInput) (res []*model.ProofRestriction, err error) {
+	res = make([]*model.ProofRestriction, len(input))
+	for i, r := range input {
+		res[i] = &model.ProofRestriction{
+			SchemaID:        valueOrEmpty(r.SchemaID),
+			SchemaIssuerDid: valueOrEmpty(r.SchemaIssuerDid),
+			SchemaName:      valueOrEmpty(r.SchemaName),
+			IssuerDid:       valueOrEmpty(r.IssuerDid),
+			CredDefID:       valueOrEmpty(r.CredDefID),
+		}
+		if *res[i] == (model.ProofRestriction{}) {
+			return nil, fmt.Errorf("empty restriction at index %d", i)
+		}
+	}
+	return res, nil
+}
+
 type Resolver struct {
-	db     store.DB
-	agency agency.Agency
+	db          store.DB
+	agency      agency.Agency
+	outbox      *outbox.Outbox
+	deadLetters *deadletter.Queue
 	*agent.Resolver
 	*update.Updater
 }
@@ -25,10 +56,12 @@ type Resolver struct {
 func NewResolver(
 	db store.DB,
 	agencyInstance agency.Agency,
+	outboxInstance *outbox.Outbox,
+	deadLetters *deadletter.Queue,
 	agentResolver *agent.Resolver,
 	updater *update.Updater,
 ) *Resolver {
-	return &Resolver{db, agencyInstance, agentResolver, updater}
+	return &Resolver{db, agencyInstance, outboxInstance, deadLetters, agentResolver, updater}
 }
 
 func (r *Resolver) MarkEventRead(ctx context.Context, input model.MarkReadInput) (e *model.Event, err error) {
@@ -53,7 +86,7 @@ func (r *Resolver) Invite(ctx context.Context) (res *model.InvitationResponse, e
 
 	tenant := try.To1(r.GetAgent(ctx))
 
-	data := try.To1(r.agency.Invite(r.AgencyAuth(tenant)))
+	data := try.To1(r.agency.Invite(ctx, r.AgencyAuth(tenant)))
 
 	res = try.To1(invitation.FromAgency(data))
 
@@ -77,7 +110,7 @@ func (r *Resolver) Connect(ctx context.Context, input model.ConnectInput) (res *
 
 	tenant := try.To1(r.GetAgent(ctx))
 
-	id := try.To1(r.agency.Connect(r.AgencyAuth(tenant), input.Invitation))
+	id := try.To1(r.agency.Connect(ctx, r.AgencyAuth(tenant), input.Invitation))
 
 	_ = try.To1(r.AddJob(
 		&dbModel.Job{
@@ -100,7 +133,8 @@ func (r *Resolver) SendMessage(ctx context.Context, input model.MessageInput) (r
 
 	tenant := try.To1(r.GetAgent(ctx))
 
-	try.To1(r.agency.SendMessage(r.AgencyAuth(tenant), input.ConnectionID, input.Message))
+	try.To1(r.db.GetConnection(input.ConnectionID, tenant.ID))
+	try.To1(r.outbox.AddMessage(ctx, tenant, input.ConnectionID, input.Message))
 
 	res = &model.Response{Ok: true}
 	return
@@ -108,19 +142,72 @@ func (r *Resolver) SendMessage(ctx context.Context, input model.MessageInput) (r
 
 func (r *Resolver) SendProofRequest(ctx context.Context, input model.ProofRequestInput) (res *model.Response, err error) {
 	defer err2.Handle(&err)
-	utils.LogLow().Info("mutationResolver:SendMessage")
+	utils.LogLow().Info("mutationResolver:SendProofRequest")
 
 	tenant := try.To1(r.GetAgent(ctx))
 
 	attributes := make([]agency.Attribute, len(input.Attributes))
 	for i, a := range input.Attributes {
+		restrictions := try.To1(toProofRestrictions(a.Restrictions))
+		for _, r := range restrictions {
+			if a.CredDefID != nil && r.CredDefID != "" && r.CredDefID != *a.CredDefID {
+				return nil, fmt.Errorf("restriction conflicts with cred def id of attribute %s", a.Name)
+			}
+		}
 		attributes[i] = agency.Attribute{
-			Name:      a.Name,
-			CredDefID: a.CredDefID,
+			Name:         a.Name,
+			CredDefID:    valueOrEmpty(a.CredDefID),
+			Restrictions: restrictions,
+		}
+	}
+
+	predicates := make([]agency.Predicate, len(input.Predicates))
+	for i, p := range input.Predicates {
+		if !predicateOperators[p.Operator] {
+			return nil, fmt.Errorf("invalid operator %s for predicate %s", p.Operator, p.Name)
+		}
+		predicates[i] = agency.Predicate{
+			Name:      p.Name,
+			Operator:  p.Operator,
+			Value:     int64(p.Value),
+			CredDefID: p.CredDefID,
+		}
+	}
+
+	try.To1(r.db.GetConnection(input.ConnectionID, tenant.ID))
+	try.To1(r.outbox.AddProofRequest(ctx, tenant, input.ConnectionID, attributes, predicates))
+
+	res = &model.Response{Ok: true}
+	return
+}
+
+func (r *Resolver) SendCredentialOffer(ctx context.Context, input model.CredentialOfferInput) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:SendCredentialOffer")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	attributes := make([]*model.CredentialValue, len(input.Attributes))
+	for i, a := range input.Attributes {
+		attributes[i] = &model.CredentialValue{
+			Name:  a.Name,
+			Value: a.Value,
 		}
 	}
 
-	try.To1(r.agency.SendProofRequest(r.AgencyAuth(tenant), input.ConnectionID, attributes))
+	try.To1(r.agency.SendCredentialOffer(ctx, r.AgencyAuth(tenant), input.ConnectionID, input.CredDefID, attributes))
+
+	res = &model.Response{Ok: true}
+	return
+}
+
+func (r *Resolver) PingConnection(ctx context.Context, connectionID string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:PingConnection")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	try.To1(r.agency.PingConnection(ctx, r.AgencyAuth(tenant), connectionID))
 
 	res = &model.Response{Ok: true}
 	return
@@ -136,17 +223,18 @@ func (r *Resolver) Resume(ctx context.Context, input model.ResumeJobInput) (res
 
 	jobInfo := &agency.JobInfo{
 		TenantID:     tenant.ID,
-		JobID:        job.ID,
+		JobID:        job.AgencyProtocolID(),
 		ConnectionID: *job.ConnectionID,
 	}
 
 	switch job.ProtocolType {
 	case model.ProtocolTypeCredential:
-		try.To(r.agency.ResumeCredentialOffer(r.AgencyAuth(tenant), jobInfo, input.Accept))
+		try.To(r.agency.ResumeCredentialOffer(ctx, r.AgencyAuth(tenant), jobInfo, input.Accept))
 	case model.ProtocolTypeProof:
-		try.To(r.agency.ResumeProofRequest(r.AgencyAuth(tenant), jobInfo, input.Accept))
+		try.To(r.agency.ResumeProofRequest(ctx, r.AgencyAuth(tenant), jobInfo, input.Accept))
 	case model.ProtocolTypeBasicMessage:
 	case model.ProtocolTypeConnection:
+	case model.ProtocolTypeTrustPing:
 	case model.ProtocolTypeNone:
 		// N/A
 		return
@@ -156,3 +244,130 @@ func (r *Resolver) Resume(ctx context.Context, input model.ResumeJobInput) (res
 
 	return res, err
 }
+
+func (r *Resolver) CancelJob(ctx context.Context, id string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:CancelJob")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	job := try.To1(r.db.GetJob(id, tenant.ID))
+	if job.Status == model.JobStatusComplete {
+		return nil, fmt.Errorf("job %s is already completed", job.ID)
+	}
+
+	jobInfo := &agency.JobInfo{
+		TenantID: tenant.ID,
+		JobID:    job.AgencyProtocolID(),
+	}
+	if job.ConnectionID != nil {
+		jobInfo.ConnectionID = *job.ConnectionID
+	}
+
+	// jobs queued in the outbox and jobs waiting for unused invitations have no protocol at agency,
+	// other jobs are cancelled only when the agency has released the protocol
+	item := try.To1(outbox.UndeliveredItem(r.db, job))
+	switch {
+	case item != nil:
+		try.To(outbox.Cancel(r.db, item))
+	case job.IsInvitation():
+	default:
+		try.To(r.agency.CancelJob(ctx, r.AgencyAuth(tenant), jobInfo, job.ProtocolType))
+	}
+
+	if job.ProtocolCredentialID != nil {
+		try.To(r.db.ArchiveCredential(*job.ProtocolCredentialID, tenant.ID))
+	}
+	if job.ProtocolProofID != nil {
+		try.To(r.db.ArchiveProof(*job.ProtocolProofID, tenant.ID))
+	}
+
+	job.Status = model.JobStatusComplete
+	job.Result = model.JobResultCancelled
+	try.To(r.UpdateJob(job, fmt.Sprintf("Protocol %s cancelled", job.ProtocolType.String())))
+
+	res = &model.Response{Ok: true}
+	return res, err
+}
+
+func (r *Resolver) RetryJob(ctx context.Context, id string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:RetryJob")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	try.To(r.outbox.Retry(ctx, tenant, id))
+
+	res = &model.Response{Ok: true}
+	return res, err
+}
+
+func (r *Resolver) SetPolicy(ctx context.Context, input model.PolicyInput) (res *model.TenantPolicy, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:SetPolicy")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	if input.Protocol != model.ProtocolTypeCredential && input.Protocol != model.ProtocolTypeProof {
+		return nil, fmt.Errorf("policies are supported only for credentials and proofs, got %s", input.Protocol)
+	}
+
+	policy := &dbModel.Policy{
+		Base:         dbModel.Base{TenantID: tenant.ID},
+		ProtocolType: input.Protocol,
+		Action:       input.Action,
+		ConnectionID: valueOrEmpty(input.ConnectionID),
+		TheirLabel:   valueOrEmpty(input.TheirLabel),
+		TheirDid:     valueOrEmpty(input.TheirDid),
+		CredDefID:    valueOrEmpty(input.CredDefID),
+		Attributes:   input.Attributes,
+	}
+	if policy.IsEmpty() {
+		return nil, fmt.Errorf("policy needs at least one condition besides the protocol")
+	}
+
+	if input.ID != nil {
+		policy.ID = *input.ID
+		policy = try.To1(r.db.UpdatePolicy(policy))
+	} else {
+		policy = try.To1(r.db.AddPolicy(policy))
+	}
+
+	return policy.ToNode(), nil
+}
+
+func (r *Resolver) RemovePolicy(ctx context.Context, id string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:RemovePolicy")
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	try.To(r.db.RemovePolicy(id, tenant.ID))
+
+	res = &model.Response{Ok: true}
+	return
+}
+
+func (r *Resolver) RetryDeadLetter(ctx context.Context, id string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:RetryDeadLetter")
+
+	try.To(r.RequireAdmin(ctx))
+
+	try.To(r.deadLetters.RetryNow(ctx, id))
+
+	res = &model.Response{Ok: true}
+	return res, err
+}
+
+func (r *Resolver) DiscardDeadLetter(ctx context.Context, id string) (res *model.Response, err error) {
+	defer err2.Handle(&err)
+	utils.LogLow().Info("mutationResolver:DiscardDeadLetter")
+
+	try.To(r.RequireAdmin(ctx))
+
+	try.To(r.deadLetters.Discard(id))
+
+	res = &model.Response{Ok: true}
+	return res, err
+}
@@ -0,0 +1,353 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/resolver/update"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+const (
+	maxAttempts    = 5
+	baseRetryDelay = 2 * time.Second
+	maxRetryDelay  = 5 * time.Minute
+	dueBatchSize   = 50
+	// claimLease keeps the item from other workers during a delivery attempt,
+	// it must exceed the agency call timeout
+	claimLease = 5 * time.Minute
+
+	deliveryFailureCode = "DELIVERY_FAILED"
+	cancelledError      = "cancelled"
+)
+
+type payload struct {
+	Message    string             `json:"message,omitempty"`
+	Attributes []agency.Attribute `json:"attributes,omitempty"`
+	Predicates []agency.Predicate `json:"predicates,omitempty"`
+}
+
+// Outbox persists outgoing agency operations before delivering them.
+// Failed deliveries are retried with exponential backoff and the progress
+// is visible to the user through the job tracking the operation.
+type Outbox struct {
+	db     store.DB
+	agency agency.Agency
+	*update.Updater
+}
+
+func NewOutbox(db store.DB, agencyInstance agency.Agency, updater *update.Updater) *Outbox {
+	return &Outbox{db, agencyInstance, updater}
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay << (attempts - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+func isSupported(protocolType model.ProtocolType) bool {
+	return protocolType == model.ProtocolTypeBasicMessage || protocolType == model.ProtocolTypeProof
+}
+
+// UndeliveredItem returns the outbox item of a job that has not been delivered to the agency yet.
+// Such job has no protocol at agency. Returns nil if the job is not queued in the outbox or it has been delivered.
+func UndeliveredItem(db store.DB, job *dbModel.Job) (item *dbModel.OutboxItem, err error) {
+	defer err2.Handle(&err)
+
+	if job.ProtocolID != nil || !job.InitiatedByUs || !isSupported(job.ProtocolType) {
+		return nil, nil
+	}
+	item, err = db.GetOutboxItem(job.ID, job.TenantID)
+	if store.ErrorCode(err) == store.ErrCodeNotFound {
+		return nil, nil
+	}
+	try.To(err)
+
+	if !item.Delivered.IsZero() {
+		return nil, nil
+	}
+	return item, nil
+}
+
+func (o *Outbox) AddMessage(
+	ctx context.Context,
+	tenant *dbModel.Agent,
+	connectionID, message string,
+) (job *dbModel.Job, err error) {
+	return o.add(ctx, tenant, connectionID, model.ProtocolTypeBasicMessage, &payload{Message: message}, "Queued basic message")
+}
+
+func (o *Outbox) AddProofRequest(
+	ctx context.Context,
+	tenant *dbModel.Agent,
+	connectionID string,
+	attributes []agency.Attribute,
+	predicates []agency.Predicate,
+) (job *dbModel.Job, err error) {
+	return o.add(
+		ctx,
+		tenant,
+		connectionID,
+		model.ProtocolTypeProof,
+		&payload{Attributes: attributes, Predicates: predicates},
+		"Queued proof request",
+	)
+}
+
+func (o *Outbox) add(
+	ctx context.Context,
+	tenant *dbModel.Agent,
+	connectionID string,
+	protocolType model.ProtocolType,
+	data *payload,
+	description string,
+) (job *dbModel.Job, err error) {
+	defer err2.Handle(&err)
+
+	// item is inserted as claimed for the first attempt done below
+	item := try.To1(o.db.AddOutboxItem(&dbModel.OutboxItem{
+		Base:         dbModel.Base{TenantID: tenant.ID},
+		ConnectionID: connectionID,
+		ProtocolType: protocolType,
+		Payload:      try.To1(json.Marshal(data)),
+		NextAttempt:  time.Now().UTC().Add(claimLease),
+	}))
+
+	utils.LogMed().Infof("Add outbox item %s (%s) for tenant %s", item.ID, protocolType, tenant.ID)
+
+	job = try.To1(o.AddJob(&dbModel.Job{
+		Base:          dbModel.Base{ID: item.ID, TenantID: tenant.ID},
+		ConnectionID:  &connectionID,
+		ProtocolType:  protocolType,
+		InitiatedByUs: true,
+		Status:        model.JobStatusWaiting,
+		Result:        model.JobResultNone,
+	}, description))
+
+	// first attempt is done right away with the user credentials,
+	// failures are left for the worker to retry
+	o.deliver(ctx, o.AgencyAuth(tenant), item)
+
+	return job, nil
+}
+
+// Retry schedules a new round of delivery attempts for a job whose delivery has failed
+func (o *Outbox) Retry(ctx context.Context, tenant *dbModel.Agent, jobID string) (err error) {
+	defer err2.Handle(&err)
+
+	job := try.To1(o.db.GetJob(jobID, tenant.ID))
+	if job.Result != model.JobResultFailure {
+		return fmt.Errorf("job %s has not failed", job.ID)
+	}
+
+	item, err := o.db.GetOutboxItem(job.ID, tenant.ID)
+	if err != nil && store.ErrorCode(err) == store.ErrCodeNotFound {
+		return fmt.Errorf("job %s has no outgoing operation to retry", job.ID)
+	}
+	try.To(err)
+
+	utils.LogMed().Infof("Retry outbox item %s for tenant %s", item.ID, tenant.ID)
+
+	item.Attempts = 0
+	item.Failed = time.Time{}
+	item.LastError = ""
+	// item is claimed for the attempt done below
+	item.NextAttempt = time.Now().UTC().Add(claimLease)
+	item = try.To1(o.db.UpdateOutboxItem(item))
+
+	job.Status = model.JobStatusWaiting
+	job.Result = model.JobResultNone
+	job.FailureCode = ""
+	job.FailureReason = ""
+	try.To(o.UpdateJob(job, "Retrying delivery"))
+
+	o.deliver(ctx, o.AgencyAuth(tenant), item)
+	return nil
+}
+
+// Cancel stops the delivery of an item whose job is closed before the item reached the agency
+func Cancel(db store.DB, item *dbModel.OutboxItem) (err error) {
+	defer err2.Handle(&err)
+
+	utils.LogMed().Infof("Cancel outbox item %s for tenant %s", item.ID, item.TenantID)
+
+	item.Failed = time.Now().UTC()
+	item.LastError = cancelledError
+	try.To1(db.UpdateOutboxItem(item))
+	return nil
+}
+
+// Run delivers the due outbox items periodically until the context is done
+func (o *Outbox) Run(ctx context.Context, interval time.Duration) {
+	utils.LogMed().Infof("Start outbox worker with interval %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogMed().Infoln("Stop outbox worker")
+			return
+		case <-ticker.C:
+		}
+		if count := o.DeliverDue(); count > 0 {
+			utils.LogMed().Infof("Delivered %d outbox items", count)
+		}
+	}
+}
+
+// DeliverDue tries to deliver the items whose retry delay has passed.
+// Each item is claimed before the attempt so that concurrent workers do not deliver the same item.
+func (o *Outbox) DeliverDue() (delivered int) {
+	for i := 0; i < dueBatchSize; i++ {
+		now := time.Now().UTC()
+		item, err := o.db.ClaimDueOutboxItem(now, now.Add(claimLease))
+		if err != nil {
+			if store.ErrorCode(err) != store.ErrCodeNotFound {
+				glog.Errorf("Unable to claim outbox item: %s", err.Error())
+			}
+			return delivered
+		}
+
+		tenant, err := o.db.GetAgent(&item.TenantID, nil)
+		if err != nil {
+			glog.Errorf("Unable to fetch tenant %s for outbox item %s: %s", item.TenantID, item.ID, err.Error())
+			continue
+		}
+		if o.deliver(context.Background(), o.BackgroundAuth(tenant), item) {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+func (o *Outbox) send(ctx context.Context, a *agency.Agent, item *dbModel.OutboxItem) (id string, err error) {
+	defer err2.Handle(&err)
+
+	data := &payload{}
+	try.To(json.Unmarshal(item.Payload, data))
+
+	switch item.ProtocolType {
+	case model.ProtocolTypeBasicMessage:
+		return o.agency.SendMessage(ctx, a, item.ConnectionID, data.Message)
+	case model.ProtocolTypeProof:
+		return o.agency.SendProofRequest(ctx, a, item.ConnectionID, data.Attributes, data.Predicates)
+	case model.ProtocolTypeNone,
+		model.ProtocolTypeConnection,
+		model.ProtocolTypeCredential,
+		model.ProtocolTypeTrustPing:
+	}
+	return "", fmt.Errorf("outbox does not support protocol %s", item.ProtocolType)
+}
+
+func (o *Outbox) deliver(ctx context.Context, a *agency.Agent, item *dbModel.OutboxItem) (ok bool) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Errorf("Error when delivering outbox item %s: %s", item.ID, err.Error())
+	}))
+
+	job := try.To1(o.db.GetJob(item.ID, item.TenantID))
+	now := time.Now().UTC()
+
+	// job might have been cancelled or expired meanwhile
+	if job.Status == model.JobStatusComplete {
+		utils.LogMed().Infof("Skip delivery of outbox item %s, job is already completed", item.ID)
+		item.Failed = now
+		try.To1(o.db.UpdateOutboxItem(item))
+		return false
+	}
+
+	item.Attempts++
+	job.Attempts++
+	protocolID, sendErr := o.send(ctx, a, item)
+
+	// job might have been cancelled during the attempt
+	if current := try.To1(o.db.GetJob(item.ID, item.TenantID)); current.Result == model.JobResultCancelled {
+		o.releaseCancelled(ctx, a, item, protocolID, sendErr)
+		return false
+	}
+
+	if sendErr == nil {
+		utils.LogMed().Infof("Delivered outbox item %s after %d attempts", item.ID, item.Attempts)
+		item.Delivered = now
+		item.LastError = ""
+		try.To1(o.db.UpdateOutboxItem(item))
+		try.To1(o.db.UpdateJob(job))
+		o.completeJob(job, protocolID)
+		return true
+	}
+
+	glog.Warningf("Delivery attempt %d for outbox item %s failed: %s", item.Attempts, item.ID, sendErr.Error())
+	item.LastError = sendErr.Error()
+	if item.Attempts >= maxAttempts {
+		item.Failed = now
+		try.To1(o.db.UpdateOutboxItem(item))
+
+		job.Status = model.JobStatusComplete
+		job.Result = model.JobResultFailure
+		job.FailureCode = deliveryFailureCode
+		job.FailureReason = item.LastError
+		try.To(o.UpdateJob(job, fmt.Sprintf("Delivery failed after %d attempts", item.Attempts)))
+		return false
+	}
+
+	item.NextAttempt = now.Add(retryDelay(item.Attempts))
+	try.To1(o.db.UpdateOutboxItem(item))
+	try.To1(o.db.UpdateJob(job))
+	return false
+}
+
+// releaseCancelled stops the delivery of an item whose job was cancelled during the delivery attempt.
+// The protocol possibly started is released and linked to the job so that its late notifications are skipped.
+func (o *Outbox) releaseCancelled(ctx context.Context, a *agency.Agent, item *dbModel.OutboxItem, protocolID string, sendErr error) {
+	utils.LogMed().Infof("Job of outbox item %s was cancelled during delivery", item.ID)
+
+	try.To(Cancel(o.db, item))
+	if sendErr != nil {
+		return
+	}
+
+	info := &agency.JobInfo{TenantID: item.TenantID, JobID: protocolID, ConnectionID: item.ConnectionID}
+	if err := o.agency.CancelJob(ctx, a, info, item.ProtocolType); err != nil {
+		glog.Warningf("Unable to release protocol %s of cancelled outbox item %s: %s", protocolID, item.ID, err.Error())
+	}
+	job := try.To1(o.db.LinkJob(item.ID, protocolID, item.TenantID))
+	job.Status = model.JobStatusComplete
+	job.Result = model.JobResultCancelled
+	try.To1(o.db.UpdateJob(job))
+}
+
+// completeJob links the job to the started protocol, the job keeps its ID and
+// tracks the protocol from now on
+func (o *Outbox) completeJob(job *dbModel.Job, protocolID string) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Errorf("Error when completing outbox job %s: %s", job.ID, err.Error())
+	}))
+
+	_, err := o.db.GetJob(protocolID, job.TenantID)
+	recorded := err == nil
+	if err != nil && store.ErrorCode(err) != store.ErrCodeNotFound {
+		try.To(err)
+	}
+
+	job = try.To1(o.db.LinkJob(job.ID, protocolID, job.TenantID))
+	if recorded {
+		return
+	}
+
+	// protocol job was not recorded, keep the outbox job as delivery receipt
+	job.Status = model.JobStatusComplete
+	job.Result = model.JobResultSuccess
+	try.To(o.UpdateJob(job, "Delivered to agency"))
+}
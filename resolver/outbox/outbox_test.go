@@ -0,0 +1,372 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	agencyMock "github.com/findy-network/findy-agent-vault/agency/mock"
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	storeMock "github.com/findy-network/findy-agent-vault/db/store/mock"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	"github.com/findy-network/findy-agent-vault/resolver/update"
+	gomock "github.com/golang/mock/gomock"
+)
+
+const (
+	testTenantID     = "tenant-id"
+	testConnectionID = "connection-id"
+	testProtocolID   = "protocol-id"
+)
+
+var testAgent = &agency.Agent{TenantID: testTenantID}
+
+func createOutbox(t *testing.T) (*Outbox, *storeMock.MockDB, *agencyMock.MockAgency) {
+	ctrl := gomock.NewController(t)
+	m := storeMock.NewMockDB(ctrl)
+	a := agencyMock.NewMockAgency(ctrl)
+	agentResolver := agent.NewResolver(m, a, "")
+	return NewOutbox(m, a, update.NewUpdater(m, agentResolver, "", nil)), m, a
+}
+
+func testItem(t *testing.T, attempts int) *dbModel.OutboxItem {
+	data, err := json.Marshal(&payload{Message: "message"})
+	if err != nil {
+		t.Fatalf("Unable to marshal payload %s", err)
+	}
+	return &dbModel.OutboxItem{
+		Base:         dbModel.Base{ID: "item-id", TenantID: testTenantID},
+		ConnectionID: testConnectionID,
+		ProtocolType: model.ProtocolTypeBasicMessage,
+		Payload:      data,
+		Attempts:     attempts,
+	}
+}
+
+func testJob(status model.JobStatus, result model.JobResult) *dbModel.Job {
+	connectionID := testConnectionID
+	return &dbModel.Job{
+		Base:          dbModel.Base{ID: "item-id", TenantID: testTenantID},
+		ConnectionID:  &connectionID,
+		ProtocolType:  model.ProtocolTypeBasicMessage,
+		InitiatedByUs: true,
+		Status:        status,
+		Result:        result,
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		exp      time.Duration
+	}{
+		{1, baseRetryDelay},
+		{2, 2 * baseRetryDelay},
+		{3, 4 * baseRetryDelay},
+		{20, maxRetryDelay},
+		{100, maxRetryDelay},
+	}
+	for _, tc := range tests {
+		if got := retryDelay(tc.attempts); got != tc.exp {
+			t.Errorf("Mismatch in retry delay for %d attempts, expected: %v got: %v", tc.attempts, tc.exp, got)
+		}
+	}
+}
+
+func TestDeliverMaxAttempts(t *testing.T) {
+	o, m, a := createOutbox(t)
+	item := testItem(t, maxAttempts-1)
+
+	m.
+		EXPECT().
+		GetJob(item.ID, testTenantID).
+		Return(testJob(model.JobStatusWaiting, model.JobResultNone), nil).
+		Times(2)
+	a.
+		EXPECT().
+		SendMessage(gomock.Any(), testAgent, testConnectionID, "message").
+		Return("", errors.New("agency unavailable"))
+	m.
+		EXPECT().
+		UpdateOutboxItem(gomock.Any()).
+		DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+			if item.Attempts != maxAttempts || item.Failed.IsZero() || item.LastError == "" {
+				t.Errorf("Outbox item not failed after max attempts: %+v", item)
+			}
+			return item, nil
+		})
+	m.
+		EXPECT().
+		UpdateJob(gomock.Any()).
+		DoAndReturn(func(job *dbModel.Job) (*dbModel.Job, error) {
+			if job.Status != model.JobStatusComplete || job.Result != model.JobResultFailure || job.FailureCode != deliveryFailureCode {
+				t.Errorf("Job not failed after max attempts: %+v", job)
+			}
+			return job, nil
+		})
+	m.
+		EXPECT().
+		AddEvent(gomock.Any()).
+		Return(&dbModel.Event{}, nil)
+
+	if o.deliver(context.Background(), testAgent, item) {
+		t.Errorf("Failed delivery reported as delivered")
+	}
+}
+
+func TestDeliverCompletedJob(t *testing.T) {
+	o, m, _ := createOutbox(t)
+	item := testItem(t, 1)
+
+	// job was cancelled or expired before the attempt, agency is not contacted
+	m.
+		EXPECT().
+		GetJob(item.ID, testTenantID).
+		Return(testJob(model.JobStatusComplete, model.JobResultExpired), nil)
+	m.
+		EXPECT().
+		UpdateOutboxItem(gomock.Any()).
+		DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+			if item.Attempts != 1 || item.Failed.IsZero() {
+				t.Errorf("Outbox item of completed job not failed: %+v", item)
+			}
+			return item, nil
+		})
+
+	if o.deliver(context.Background(), testAgent, item) {
+		t.Errorf("Delivery of completed job reported as delivered")
+	}
+}
+
+func TestDeliverCancelledJob(t *testing.T) {
+	o, m, a := createOutbox(t)
+	item := testItem(t, 0)
+	protocolID := testProtocolID
+	linked := testJob(model.JobStatusWaiting, model.JobResultNone)
+	linked.ProtocolID = &protocolID
+
+	// job is cancelled while the message is sent, the started protocol is released
+	gomock.InOrder(
+		m.
+			EXPECT().
+			GetJob(item.ID, testTenantID).
+			Return(testJob(model.JobStatusWaiting, model.JobResultNone), nil),
+		a.
+			EXPECT().
+			SendMessage(gomock.Any(), testAgent, testConnectionID, "message").
+			Return(testProtocolID, nil),
+		m.
+			EXPECT().
+			GetJob(item.ID, testTenantID).
+			Return(testJob(model.JobStatusComplete, model.JobResultCancelled), nil),
+		m.
+			EXPECT().
+			UpdateOutboxItem(gomock.Any()).
+			DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+				if item.Failed.IsZero() || !item.Delivered.IsZero() {
+					t.Errorf("Outbox item of cancelled job not failed: %+v", item)
+				}
+				return item, nil
+			}),
+		a.
+			EXPECT().
+			CancelJob(
+				gomock.Any(),
+				testAgent,
+				&agency.JobInfo{TenantID: testTenantID, JobID: testProtocolID, ConnectionID: testConnectionID},
+				model.ProtocolTypeBasicMessage,
+			).
+			Return(nil),
+		m.
+			EXPECT().
+			LinkJob(item.ID, testProtocolID, testTenantID).
+			Return(linked, nil),
+		m.
+			EXPECT().
+			UpdateJob(gomock.Any()).
+			DoAndReturn(func(job *dbModel.Job) (*dbModel.Job, error) {
+				if job.Result != model.JobResultCancelled {
+					t.Errorf("Linked job is not cancelled: %+v", job)
+				}
+				return job, nil
+			}),
+	)
+
+	if o.deliver(context.Background(), testAgent, item) {
+		t.Errorf("Delivery of cancelled job reported as delivered")
+	}
+}
+
+func TestCancel(t *testing.T) {
+	_, m, _ := createOutbox(t)
+	item := testItem(t, 1)
+
+	m.
+		EXPECT().
+		UpdateOutboxItem(gomock.Any()).
+		DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+			if item.Failed.IsZero() || item.IsPending() {
+				t.Errorf("Cancelled outbox item is still pending: %+v", item)
+			}
+			return item, nil
+		})
+
+	if err := Cancel(m, item); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+}
+
+func TestUndeliveredItem(t *testing.T) {
+	_, m, _ := createOutbox(t)
+	protocolID := testProtocolID
+	queued := testJob(model.JobStatusWaiting, model.JobResultNone)
+	delivered := testJob(model.JobStatusWaiting, model.JobResultNone)
+	delivered.ProtocolID = &protocolID
+	received := testJob(model.JobStatusWaiting, model.JobResultNone)
+	received.InitiatedByUs = false
+
+	m.
+		EXPECT().
+		GetOutboxItem(queued.ID, testTenantID).
+		Return(testItem(t, 1), nil)
+
+	if item, err := UndeliveredItem(m, queued); err != nil || item == nil {
+		t.Errorf("Expected undelivered item for queued job, got %v %v", item, err)
+	}
+	// linked and received jobs are not looked up from the outbox
+	for _, job := range []*dbModel.Job{delivered, received} {
+		if item, err := UndeliveredItem(m, job); err != nil || item != nil {
+			t.Errorf("Expected no undelivered item for job %+v, got %v %v", job, item, err)
+		}
+	}
+}
+
+func TestCompleteJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded bool
+	}{
+		{"recorded protocol job", true},
+		{"unrecorded protocol job", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o, m, _ := createOutbox(t)
+			job := testJob(model.JobStatusWaiting, model.JobResultNone)
+			protocolID := testProtocolID
+			linked := testJob(model.JobStatusWaiting, model.JobResultNone)
+			linked.ProtocolID = &protocolID
+
+			if tc.recorded {
+				m.
+					EXPECT().
+					GetJob(testProtocolID, testTenantID).
+					Return(&dbModel.Job{Base: dbModel.Base{ID: testProtocolID, TenantID: testTenantID}}, nil)
+			} else {
+				m.
+					EXPECT().
+					GetJob(testProtocolID, testTenantID).
+					Return(nil, store.NewError(store.ErrCodeNotFound, "no rows returned"))
+			}
+			m.
+				EXPECT().
+				LinkJob(job.ID, testProtocolID, testTenantID).
+				Return(linked, nil)
+			// recorded protocol job tracks the progress,
+			// otherwise the outbox job is completed as delivery receipt
+			if !tc.recorded {
+				m.
+					EXPECT().
+					UpdateJob(gomock.Any()).
+					DoAndReturn(func(job *dbModel.Job) (*dbModel.Job, error) {
+						if job.Status != model.JobStatusComplete || job.Result != model.JobResultSuccess {
+							t.Errorf("Outbox job not completed: %+v", job)
+						}
+						return job, nil
+					})
+				m.
+					EXPECT().
+					AddEvent(gomock.Any()).
+					Return(&dbModel.Event{}, nil)
+			}
+
+			o.completeJob(job, testProtocolID)
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	o, m, a := createOutbox(t)
+	item := testItem(t, maxAttempts)
+	item.Failed = time.Now().UTC()
+	failedJob := testJob(model.JobStatusComplete, model.JobResultFailure)
+	failedJob.FailureCode = deliveryFailureCode
+
+	gomock.InOrder(
+		m.
+			EXPECT().
+			GetJob(failedJob.ID, testTenantID).
+			Return(failedJob, nil),
+		m.
+			EXPECT().
+			GetOutboxItem(failedJob.ID, testTenantID).
+			Return(item, nil),
+		m.
+			EXPECT().
+			UpdateOutboxItem(gomock.Any()).
+			DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+				if item.Attempts != 0 || !item.Failed.IsZero() {
+					t.Errorf("Outbox item not reset for retry: %+v", item)
+				}
+				return item, nil
+			}),
+		m.
+			EXPECT().
+			UpdateJob(gomock.Any()).
+			DoAndReturn(func(job *dbModel.Job) (*dbModel.Job, error) {
+				if job.Status != model.JobStatusWaiting || job.FailureCode != "" {
+					t.Errorf("Job not reset for retry: %+v", job)
+				}
+				return job, nil
+			}),
+		m.
+			EXPECT().
+			AddEvent(gomock.Any()).
+			Return(&dbModel.Event{}, nil),
+		// first new attempt fails and is scheduled for the worker
+		m.
+			EXPECT().
+			GetJob(item.ID, testTenantID).
+			Return(testJob(model.JobStatusWaiting, model.JobResultNone), nil),
+		a.
+			EXPECT().
+			SendMessage(gomock.Any(), gomock.Any(), testConnectionID, "message").
+			Return("", errors.New("agency unavailable")),
+		m.
+			EXPECT().
+			GetJob(item.ID, testTenantID).
+			Return(testJob(model.JobStatusWaiting, model.JobResultNone), nil),
+		m.
+			EXPECT().
+			UpdateOutboxItem(gomock.Any()).
+			DoAndReturn(func(item *dbModel.OutboxItem) (*dbModel.OutboxItem, error) {
+				if item.Attempts != 1 || !item.Failed.IsZero() || item.NextAttempt.IsZero() {
+					t.Errorf("Outbox item not scheduled for next attempt: %+v", item)
+				}
+				return item, nil
+			}),
+		m.
+			EXPECT().
+			UpdateJob(gomock.Any()).
+			Return(failedJob, nil),
+	)
+
+	if err := o.Retry(context.Background(), &dbModel.Agent{Base: dbModel.Base{ID: testTenantID}}, failedJob.ID); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+}
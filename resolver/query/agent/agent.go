@@ -2,22 +2,51 @@ package agent
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	"github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/paginator"
+	"github.com/findy-network/findy-common-go/jwt"
 	"github.com/lainio/err2"
 	"github.com/lainio/err2/try"
 )
 
+// touchInterval limits how often the agency is told about the agent access,
+// it must be much shorter than the idle timeout of the listeners
+const touchInterval = time.Minute
+
+var errNotAdmin = errors.New("operation is allowed only for the vault admin")
+
 type Resolver struct {
 	db     store.DB
 	agency agency.Agency
+	// adminID is the agent ID of the vault admin, empty if there is no admin
+	adminID string
+	// touched holds the time the agency was last told about the access per tenant
+	touched sync.Map
+	// prunedAt is the time in nanoseconds the expired touches were last removed
+	prunedAt atomic.Int64
+}
+
+func NewResolver(db store.DB, agencyInstance agency.Agency, adminID string) *Resolver {
+	return &Resolver{db: db, agency: agencyInstance, adminID: adminID}
 }
 
-func NewResolver(db store.DB, agencyInstance agency.Agency) *Resolver {
-	return &Resolver{db: db, agency: agencyInstance}
+// RequireAdmin fails unless the authenticated user is the vault admin.
+// The admin is not registered as a tenant.
+func (r *Resolver) RequireAdmin(ctx context.Context) (err error) {
+	defer err2.Handle(&err)
+
+	token := try.To1(jwt.TokenFromContext(ctx, "user"))
+	if r.adminID == "" || token.AgentID != r.adminID {
+		return errNotAdmin
+	}
+	return nil
 }
 
 func (r *Resolver) GetAgent(ctx context.Context) (agent *model.Agent, err error) {
@@ -25,30 +54,70 @@ func (r *Resolver) GetAgent(ctx context.Context) (agent *model.Agent, err error)
 
 	agent = try.To1(store.GetAgent(ctx, r.db))
 
-	// make sure we are listening events for this agent
-	if agent.IsNewOnboard() {
-		try.To(r.agency.AddAgent(r.AgencyAuth(agent)))
+	// make sure we are listening events for this agent,
+	// listeners of idle agents are resumed on access
+	now := time.Now()
+	if last, ok := r.touched.Load(agent.ID); ok && now.Sub(last.(time.Time)) < touchInterval {
+		return
 	}
+	try.To(r.agency.AddAgent(r.AgencyAuth(agent)))
+	r.touched.Store(agent.ID, now)
+	r.pruneTouched(now)
 	return
 }
 
+// pruneTouched removes the touches older than the touch interval once per interval,
+// so that the touches do not pile up for every tenant that has accessed the vault
+func (r *Resolver) pruneTouched(now time.Time) {
+	last := r.prunedAt.Load()
+	if now.Sub(time.Unix(0, last)) < touchInterval || !r.prunedAt.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	r.touched.Range(func(key, value any) bool {
+		if now.Sub(value.(time.Time)) >= touchInterval {
+			r.touched.Delete(key)
+		}
+		return true
+	})
+}
+
 func (r *Resolver) AgencyAuth(agent *model.Agent) *agency.Agent {
 	return &agency.Agent{
-		Label:    agent.Label,
-		RawJWT:   agent.RawJWT,
-		TenantID: agent.ID,
-		AgentID:  agent.AgentID,
+		Label:        agent.Label,
+		RawJWT:       agent.RawJWT,
+		TenantID:     agent.ID,
+		AgentID:      agent.AgentID,
+		LastAccessed: agent.LastAccessed,
 	}
 }
 
+// BackgroundAuth returns the agency auth for acting on behalf of the agent
+// when there is no user session, e.g. in background workers
+func (r *Resolver) BackgroundAuth(agent *model.Agent) *agency.Agent {
+	a := r.AgencyAuth(agent)
+	a.RawJWT = jwt.BuildJWT(agent.AgentID)
+	return a
+}
+
+func (r *Resolver) AgencyHealth() *agency.Health {
+	return r.agency.Health()
+}
+
 func (r *Resolver) FetchAgents() []*agency.Agent {
+	return try.To1(r.ListAgents())
+}
+
+// ListAgents returns all agents for the listeners
+func (r *Resolver) ListAgents() (listenerAgents []*agency.Agent, err error) {
+	defer err2.Handle(&err, "ListAgents")
+
 	nextPage := true
 	after := uint64(0)
 	allAgents := make([]*model.Agent, 0)
 	for nextPage {
-		agents, err := r.db.GetListenerAgents(&paginator.BatchInfo{Count: 50, After: after})
-		if err != nil && store.ErrorCode(err) != store.ErrCodeNotFound {
-			panic(err)
+		agents, fetchErr := r.db.GetListenerAgents(&paginator.BatchInfo{Count: 50, After: after})
+		if fetchErr != nil && store.ErrorCode(fetchErr) != store.ErrCodeNotFound {
+			return nil, fetchErr
 		}
 		count := len(agents.Agents)
 		if count > 0 {
@@ -60,9 +129,20 @@ func (r *Resolver) FetchAgents() []*agency.Agent {
 		}
 	}
 
-	listenerAgents := make([]*agency.Agent, len(allAgents))
-	for index := range allAgents {
-		listenerAgents[index] = r.AgencyAuth(allAgents[index])
+	return r.toListenerAgents(allAgents), nil
+}
+
+// FetchAccessedAgents returns the agents that have accessed the vault after the given time
+func (r *Resolver) FetchAccessedAgents(since time.Time) (listenerAgents []*agency.Agent, err error) {
+	defer err2.Handle(&err, "FetchAccessedAgents")
+
+	return r.toListenerAgents(try.To1(r.db.GetAccessedAgents(since))), nil
+}
+
+func (r *Resolver) toListenerAgents(agents []*model.Agent) []*agency.Agent {
+	listenerAgents := make([]*agency.Agent, len(agents))
+	for index := range agents {
+		listenerAgents[index] = r.AgencyAuth(agents[index])
 	}
 	return listenerAgents
 }
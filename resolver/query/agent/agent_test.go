@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneTouched(t *testing.T) {
+	r := NewResolver(nil, nil, "")
+	now := time.Now()
+	r.touched.Store("recent", now.Add(-touchInterval/2))
+	r.touched.Store("expired", now.Add(-2*touchInterval))
+
+	r.pruneTouched(now)
+	if _, ok := r.touched.Load("expired"); ok {
+		t.Errorf("Expired touch was not removed")
+	}
+	if _, ok := r.touched.Load("recent"); !ok {
+		t.Errorf("Recent touch was removed")
+	}
+
+	// touches are pruned once per interval
+	r.touched.Store("expired", now.Add(-2*touchInterval))
+	r.pruneTouched(now.Add(touchInterval / 2))
+	if _, ok := r.touched.Load("expired"); !ok {
+		t.Errorf("Touches were pruned again within the interval")
+	}
+	r.pruneTouched(now.Add(touchInterval))
+	if _, ok := r.touched.Load("expired"); ok {
+		t.Errorf("Expired touch was not removed after the interval")
+	}
+}
@@ -3,11 +3,13 @@ package query
 import (
 	"context"
 	"encoding/base64"
+	"strconv"
 
 	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/findy-network/findy-agent-vault/resolver/invitation"
+	"github.com/findy-network/findy-agent-vault/resolver/leader"
 	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
 	"github.com/findy-network/findy-agent-vault/utils"
 	"github.com/lainio/err2"
@@ -15,12 +17,13 @@ import (
 )
 
 type Resolver struct {
-	db store.DB
+	db      store.DB
+	elector *leader.Elector
 	*agent.Resolver
 }
 
-func NewResolver(db store.DB, agentResolver *agent.Resolver) *Resolver {
-	return &Resolver{db, agentResolver}
+func NewResolver(db store.DB, elector *leader.Elector, agentResolver *agent.Resolver) *Resolver {
+	return &Resolver{db, elector, agentResolver}
 }
 
 func (r *Resolver) Connections(ctx context.Context, after, before *string, first, last *int) (c *model.PairwiseConnection, err error) {
@@ -184,6 +187,67 @@ func (r *Resolver) Job(ctx context.Context, id string) (e *model.Job, err error)
 	return job.ToNode(), nil
 }
 
+func (r *Resolver) Policies(ctx context.Context) (p []*model.TenantPolicy, err error) {
+	defer err2.Handle(&err)
+
+	tenant := try.To1(r.GetAgent(ctx))
+
+	utils.LogLow().Info("queryResolver:Policies for tenant: ", tenant.ID)
+
+	policies := try.To1(r.db.GetPolicies(tenant.ID))
+
+	p = make([]*model.TenantPolicy, len(policies))
+	for index, policy := range policies {
+		p[index] = policy.ToNode()
+	}
+	return p, nil
+}
+
+func (r *Resolver) DeadLetters(ctx context.Context) (d []*model.DeadLetter, err error) {
+	defer err2.Handle(&err)
+
+	utils.LogLow().Info("queryResolver:DeadLetters")
+
+	try.To(r.RequireAdmin(ctx))
+
+	letters := try.To1(r.db.GetDeadLetters(nil))
+
+	d = make([]*model.DeadLetter, len(letters))
+	for index, letter := range letters {
+		d[index] = letter.ToNode()
+	}
+	return d, nil
+}
+
+// Status collects the service health, it is shared with the health endpoint
+func (r *Resolver) Status() *model.SystemStatus {
+	health := r.AgencyHealth()
+
+	var retryAt *string
+	if !health.RetryAt.IsZero() {
+		value := strconv.FormatInt(health.RetryAt.UnixMilli(), 10)
+		retryAt = &value
+	}
+	return &model.SystemStatus{
+		Version: utils.Version,
+		Agency: &model.AgencyStatus{
+			Available: health.Breaker != model.BreakerStateOpen,
+			// half-open breaker lets only the trial call through
+			Degraded:  health.Breaker != model.BreakerStateClosed,
+			Breaker:   health.Breaker,
+			Failures:  health.Failures,
+			RetryAtMs: retryAt,
+		},
+		HookLeader: r.elector.Status(),
+	}
+}
+
+func (r *Resolver) SystemStatus(_ context.Context) (*model.SystemStatus, error) {
+	utils.LogLow().Info("queryResolver:SystemStatus")
+
+	return r.Status(), nil
+}
+
 func (r *Resolver) User(ctx context.Context) (u *model.User, err error) {
 	defer err2.Handle(&err)
 
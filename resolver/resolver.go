@@ -1,13 +1,21 @@
 package resolver
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	"github.com/findy-network/findy-agent-vault/db/fake"
 	"github.com/findy-network/findy-agent-vault/db/store"
 	"github.com/findy-network/findy-agent-vault/db/store/pg"
+	"github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/resolver/archive"
+	"github.com/findy-network/findy-agent-vault/resolver/deadletter"
+	"github.com/findy-network/findy-agent-vault/resolver/leader"
 	"github.com/findy-network/findy-agent-vault/resolver/listen"
 	"github.com/findy-network/findy-agent-vault/resolver/mutation"
+	"github.com/findy-network/findy-agent-vault/resolver/outbox"
 	"github.com/findy-network/findy-agent-vault/resolver/query"
 	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
 	"github.com/findy-network/findy-agent-vault/resolver/query/credential"
@@ -22,8 +30,10 @@ import (
 	"github.com/findy-network/findy-agent-vault/resolver/query/pairwiseconn"
 	"github.com/findy-network/findy-agent-vault/resolver/query/proof"
 	"github.com/findy-network/findy-agent-vault/resolver/query/proofconn"
+	"github.com/findy-network/findy-agent-vault/resolver/shard"
 	"github.com/findy-network/findy-agent-vault/resolver/update"
 	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
 )
 
 //go:generate go run github.com/99designs/gqlgen
@@ -56,6 +66,15 @@ type Resolver struct {
 	updater  *update.Updater
 	listener *listen.Listener
 	archiver *archive.Archiver
+	outbox   *outbox.Outbox
+
+	deadLetters *deadletter.Queue
+	elector     *leader.Elector
+	shards      *shard.Membership
+
+	// background workers are stopped on close
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 
 	resolvers *controller
 }
@@ -65,8 +84,16 @@ func InitResolverWithDB(config *utils.Configuration, coreAgency agency.Agency, d
 
 	r.agency = coreAgency
 
-	agentResolver := agent.NewResolver(db, r.agency)
-	updater := update.NewUpdater(db, agentResolver)
+	agentResolver := agent.NewResolver(db, r.agency, config.DeadLetterAdminID)
+	// events are published to the other replicas when they are listened
+	var origin string
+	if config.EventListenerPingInterval > 0 {
+		origin = config.InstanceID
+	}
+	updater := update.NewUpdater(db, agentResolver, origin, update.NewJobTimeouts(config))
+	r.outbox = outbox.NewOutbox(db, r.agency, updater)
+	r.deadLetters = deadletter.NewQueue(db, r.agency, agentResolver)
+	r.elector = leader.NewElector(db, r.agency, config.InstanceID, config.AgencyMainSubscriber)
 	r.resolvers = &controller{
 		agent:                agentResolver,
 		message:              message.NewResolver(db, agentResolver),
@@ -77,22 +104,77 @@ func InitResolverWithDB(config *utils.Configuration, coreAgency agency.Agency, d
 		jobConnection:        jobconn.NewResolver(db, agentResolver),
 		job:                  job.NewResolver(db, agentResolver),
 		messageConnection:    messageconn.NewResolver(db, agentResolver),
-		mutation:             mutation.NewResolver(db, r.agency, agentResolver, updater),
+		mutation:             mutation.NewResolver(db, r.agency, r.outbox, r.deadLetters, agentResolver, updater),
 		proofConnection:      proofconn.NewResolver(db, agentResolver),
 		proof:                proof.NewResolver(db, agentResolver),
 		pairwiseConnection:   pairwiseconn.NewResolver(db, agentResolver),
 		pairwise:             pairwise.NewResolver(db, agentResolver),
-		query:                query.NewResolver(db, agentResolver),
+		query:                query.NewResolver(db, r.elector, agentResolver),
 	}
 	r.updater = updater
 
-	r.listener = listen.NewListener(db, r.updater)
+	r.listener = listen.NewListener(db, r.agency, r.updater)
 	r.archiver = archive.NewArchiver(db)
-	r.agency.Init(r.listener, agentResolver.FetchAgents(), r.archiver, config)
+
+	var workerCtx context.Context
+	workerCtx, r.stopWorkers = context.WithCancel(context.Background())
+	agents := agentResolver.FetchAgents()
+	if config.ReplicaHeartbeatInterval > 0 {
+		// listeners are started for the tenants owned by this replica
+		interval := time.Duration(config.ReplicaHeartbeatInterval) * time.Second
+		r.shards = shard.NewMembership(db, r.agency, agentResolver, config.InstanceID)
+		if err := r.shards.Join(workerCtx, interval); err != nil {
+			glog.Errorf("Unable to join vault replicas, listening all tenants: %s", err.Error())
+		}
+		r.agency.Init(r.listener, nil, r.archiver, config)
+		r.agency.Rebalance(r.shards.Owns, agents)
+		r.startWorker(workerCtx, interval, r.shards.Run)
+	} else {
+		r.agency.Init(r.listener, agents, r.archiver, config)
+	}
+	if config.JobSweepInterval > 0 {
+		// with leader election only the elected leader sweeps
+		var isLeader func() bool
+		if config.LeaderElectionInterval > 0 {
+			isLeader = r.elector.IsLeader
+		}
+		r.startWorker(workerCtx, time.Duration(config.JobSweepInterval)*time.Second, func(ctx context.Context, interval time.Duration) {
+			r.listener.SweepExpiredJobs(ctx, interval, isLeader)
+		})
+	}
+	if config.ProcessedNotificationDays > 0 {
+		retention := time.Duration(config.ProcessedNotificationDays) * 24 * time.Hour
+		r.startWorker(workerCtx, listen.CleanupInterval, func(ctx context.Context, interval time.Duration) {
+			r.listener.CleanProcessedNotifications(ctx, interval, retention)
+		})
+	}
+	if config.OutboxInterval > 0 {
+		r.startWorker(workerCtx, time.Duration(config.OutboxInterval)*time.Second, r.outbox.Run)
+	}
+	if config.DeadLetterInterval > 0 {
+		r.startWorker(workerCtx, time.Duration(config.DeadLetterInterval)*time.Second, r.deadLetters.Run)
+	}
+	if config.EventListenerPingInterval > 0 {
+		r.startWorker(workerCtx, time.Duration(config.EventListenerPingInterval)*time.Second, r.updater.Run)
+	}
+	if config.LeaderElectionInterval > 0 {
+		r.startWorker(workerCtx, time.Duration(config.LeaderElectionInterval)*time.Second, r.elector.Run)
+	}
+	if !config.AgencyMainSubscriber || config.LeaderElectionInterval <= 0 {
+		glog.Warningln("DEV mode: Skipping subscribing to PSM hook.")
+	}
 
 	return r
 }
 
+func (r *Resolver) startWorker(ctx context.Context, interval time.Duration, run func(context.Context, time.Duration)) {
+	r.workers.Add(1)
+	go func() {
+		defer r.workers.Done()
+		run(ctx, interval)
+	}()
+}
+
 func InitResolver(config *utils.Configuration, coreAgency agency.Agency) *Resolver {
 	db := pg.InitDB(config, false, false)
 	if config.GenerateFakeData {
@@ -101,12 +183,35 @@ func InitResolver(config *utils.Configuration, coreAgency agency.Agency) *Resolv
 	return InitResolverWithDB(config, coreAgency, db)
 }
 
+// Status returns the service health for the health endpoint
+func (r *Resolver) Status() *model.SystemStatus {
+	return r.resolvers.query.Status()
+}
+
 // For testing
 func (r *Resolver) Store() store.DB {
 	return r.db
 }
 
-func (r *Resolver) Close() {
+// Close shuts down the service in order: background workers are stopped,
+// agency notifications received so far are handled, subscriptions are ended
+// and finally the database is closed.
+func (r *Resolver) Close(ctx context.Context) (err error) {
+	r.stopWorkers()
+	if err = utils.Wait(ctx, &r.workers); err != nil {
+		glog.Errorf("Background workers did not stop before shutdown: %s", err.Error())
+	}
+
+	if agencyErr := r.agency.Close(ctx); agencyErr != nil {
+		glog.Errorf("Error when closing agency: %s", agencyErr.Error())
+		err = agencyErr
+	}
+	// jobs resumed by policies during the drain are left pending if the agency is already closed
+	if waitErr := r.listener.Wait(ctx); waitErr != nil {
+		glog.Errorf("Policy resumes did not complete before shutdown: %s", waitErr.Error())
+	}
+
+	r.updater.Close()
 	r.db.Close()
-	// TODO: close agency connection (and other open connections)
+	return err
 }
@@ -66,10 +66,42 @@ func (r *mutationResolver) SendProofRequest(ctx context.Context, input model.Pro
 	return r.resolvers.mutation.SendProofRequest(ctx, input)
 }
 
+func (r *mutationResolver) SendCredentialOffer(ctx context.Context, input model.CredentialOfferInput) (*model.Response, error) {
+	return r.resolvers.mutation.SendCredentialOffer(ctx, input)
+}
+
+func (r *mutationResolver) PingConnection(ctx context.Context, connectionID string) (*model.Response, error) {
+	return r.resolvers.mutation.PingConnection(ctx, connectionID)
+}
+
 func (r *mutationResolver) Resume(ctx context.Context, input model.ResumeJobInput) (*model.Response, error) {
 	return r.resolvers.mutation.Resume(ctx, input)
 }
 
+func (r *mutationResolver) CancelJob(ctx context.Context, id string) (*model.Response, error) {
+	return r.resolvers.mutation.CancelJob(ctx, id)
+}
+
+func (r *mutationResolver) RetryJob(ctx context.Context, id string) (*model.Response, error) {
+	return r.resolvers.mutation.RetryJob(ctx, id)
+}
+
+func (r *mutationResolver) SetPolicy(ctx context.Context, input model.PolicyInput) (*model.TenantPolicy, error) {
+	return r.resolvers.mutation.SetPolicy(ctx, input)
+}
+
+func (r *mutationResolver) RemovePolicy(ctx context.Context, id string) (*model.Response, error) {
+	return r.resolvers.mutation.RemovePolicy(ctx, id)
+}
+
+func (r *mutationResolver) RetryDeadLetter(ctx context.Context, id string) (*model.Response, error) {
+	return r.resolvers.mutation.RetryDeadLetter(ctx, id)
+}
+
+func (r *mutationResolver) DiscardDeadLetter(ctx context.Context, id string) (*model.Response, error) {
+	return r.resolvers.mutation.DiscardDeadLetter(ctx, id)
+}
+
 func (r *pairwiseResolver) Messages(ctx context.Context, obj *model.Pairwise, after *string, before *string, first *int, last *int) (*model.BasicMessageConnection, error) {
 	return r.resolvers.pairwise.Messages(ctx, obj, after, before, first, last)
 }
@@ -146,6 +178,18 @@ func (r *queryResolver) Job(ctx context.Context, id string) (*model.Job, error)
 	return r.resolvers.query.Job(ctx, id)
 }
 
+func (r *queryResolver) Policies(ctx context.Context) ([]*model.TenantPolicy, error) {
+	return r.resolvers.query.Policies(ctx)
+}
+
+func (r *queryResolver) DeadLetters(ctx context.Context) ([]*model.DeadLetter, error) {
+	return r.resolvers.query.DeadLetters(ctx)
+}
+
+func (r *queryResolver) SystemStatus(ctx context.Context) (*model.SystemStatus, error) {
+	return r.resolvers.query.SystemStatus(ctx)
+}
+
 func (r *queryResolver) User(ctx context.Context) (*model.User, error) {
 	return r.resolvers.query.User(ctx)
 }
@@ -154,8 +198,8 @@ func (r *queryResolver) Endpoint(ctx context.Context, payload string) (*model.In
 	return r.resolvers.query.Endpoint(ctx, payload)
 }
 
-func (r *subscriptionResolver) EventAdded(ctx context.Context) (<-chan *model.EventEdge, error) {
-	return r.updater.EventAdded(ctx)
+func (r *subscriptionResolver) EventAdded(ctx context.Context, after *string) (<-chan *model.EventEdge, error) {
+	return r.updater.EventAdded(ctx, after)
 }
 
 // BasicMessage returns generated.BasicMessageResolver implementation.
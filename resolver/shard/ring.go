@@ -0,0 +1,77 @@
+package shard
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// virtualNodes is the number of points per replica on the ring,
+// more points give more even distribution of the tenants
+const virtualNodes = 128
+
+type point struct {
+	hash    uint64
+	replica string
+}
+
+// Ring assigns tenants to replicas with consistent hashing:
+// when a replica joins or leaves only the tenants of that replica move.
+type Ring struct {
+	replicas []string
+	points   []point
+}
+
+func hash(value string) uint64 {
+	sum := sha256.Sum256([]byte(value))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+func NewRing(replicas []string) *Ring {
+	sorted := slices.Clone(replicas)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	points := make([]point, 0, len(sorted)*virtualNodes)
+	for _, replica := range sorted {
+		for i := 0; i < virtualNodes; i++ {
+			points = append(points, point{hash(replica + "#" + strconv.Itoa(i)), replica})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].hash < points[j].hash
+	})
+	return &Ring{replicas: sorted, points: points}
+}
+
+// Owner returns the replica responsible for the tenant or empty string if the ring is empty
+func (r *Ring) Owner(tenantID string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash(tenantID)
+	index := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	if index == len(r.points) {
+		index = 0
+	}
+	return r.points[index].replica
+}
+
+func (r *Ring) Replicas() []string {
+	return r.replicas
+}
+
+// Key identifies the replica set of the ring
+func (r *Ring) Key() string {
+	return strings.Join(r.replicas, ",")
+}
+
+// Equal reports if the rings have the same replicas
+func (r *Ring) Equal(other *Ring) bool {
+	return other != nil && slices.Equal(r.replicas, other.replicas)
+}
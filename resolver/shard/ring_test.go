@@ -0,0 +1,49 @@
+package shard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRingOwner(t *testing.T) {
+	if owner := NewRing(nil).Owner("tenant"); owner != "" {
+		t.Errorf("Empty ring should not have owners, got %s", owner)
+	}
+
+	replicas := []string{"vault-a", "vault-b", "vault-c"}
+	ring := NewRing(replicas)
+	reversed := NewRing([]string{"vault-c", "vault-b", "vault-a", "vault-a"})
+	if !ring.Equal(reversed) {
+		t.Errorf("Ring should not depend on replica order, got %v and %v", ring.Replicas(), reversed.Replicas())
+	}
+
+	const tenantCount = 3000
+	counts := make(map[string]int)
+	for i := 0; i < tenantCount; i++ {
+		tenantID := fmt.Sprintf("tenant-%d", i)
+		owner := ring.Owner(tenantID)
+		if owner != reversed.Owner(tenantID) {
+			t.Fatalf("Mismatch in owner of %s", tenantID)
+		}
+		counts[owner]++
+	}
+	for _, replica := range replicas {
+		// each replica should get roughly one third of the tenants
+		if counts[replica] < tenantCount/5 || counts[replica] > tenantCount/2 {
+			t.Errorf("Uneven distribution of tenants %v", counts)
+		}
+	}
+}
+
+func TestRingRebalance(t *testing.T) {
+	before := NewRing([]string{"vault-a", "vault-b"})
+	after := NewRing([]string{"vault-a", "vault-b", "vault-c"})
+
+	for i := 0; i < 1000; i++ {
+		tenantID := fmt.Sprintf("tenant-%d", i)
+		// only tenants of the new replica move
+		if owner := after.Owner(tenantID); owner != "vault-c" && owner != before.Owner(tenantID) {
+			t.Errorf("Tenant %s moved from %s to %s", tenantID, before.Owner(tenantID), owner)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package shard
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// missedHeartbeats is the number of heartbeats a replica can miss before it is considered dead
+const missedHeartbeats = 3
+
+// Membership registers this vault replica in the database and assigns the tenant listeners
+// among the live replicas, so that each tenant is listened by a single replica.
+//
+// Tenants are rebalanced when replicas join or leave. The tenants a replica loses are
+// released on its next heartbeat, after which the replica records the ring it has applied.
+// The tenants a replica gains are opened only when all live replicas have applied the ring,
+// or after the handoff timeout if some replica does not report back, so the old and the new
+// owner do not listen to the same tenant. Notifications that the old owner was handling
+// when its listener was released may still be delivered to the new owner: the notification
+// states are claimed before handling, so the same state is not processed twice
+// while its record is retained.
+type Membership struct {
+	db         store.DB
+	agency     agency.Agency
+	agents     *agent.Resolver
+	instanceID string
+
+	// synced, applied and handoffStarted are used only by the heartbeat loop
+	synced         time.Time
+	applied        string
+	handoffStarted time.Time
+
+	mu   sync.RWMutex
+	ring *Ring
+	// held limits the owned tenants to the ones listened before the latest change
+	// until the handoff is complete, nil when there is no handoff in progress
+	held func(tenantID string) bool
+}
+
+func NewMembership(db store.DB, agencyInstance agency.Agency, agentResolver *agent.Resolver, instanceID string) *Membership {
+	return &Membership{db: db, agency: agencyInstance, agents: agentResolver, instanceID: instanceID}
+}
+
+// Join registers this replica and loads the live replicas
+func (m *Membership) Join(ctx context.Context, interval time.Duration) (err error) {
+	defer err2.Handle(&err, "Join")
+
+	m.synced = time.Now().UTC()
+	try.To(m.db.UpdateReplica(ctx, m.instanceID, ""))
+	replicas := try.To1(m.db.GetReplicas(ctx, missedHeartbeats*interval))
+
+	// no listeners are open yet, so there is nothing to release before applying the ring
+	m.refresh(replicas, func(string) bool { return false })
+	try.To(m.apply(ctx))
+	m.completeHandoff(replicas, interval)
+	return nil
+}
+
+// Owns reports if the tenant is assigned to this replica.
+// All tenants are owned until the replicas are known.
+func (m *Membership) Owns(tenantID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.owns(m.ring, m.held, tenantID)
+}
+
+func (m *Membership) owns(ring *Ring, held func(string) bool, tenantID string) bool {
+	if ring == nil {
+		return true
+	}
+	return ring.Owner(tenantID) == m.instanceID && (held == nil || held(tenantID))
+}
+
+// refresh updates the ring from the live replicas. Holding tells the tenants that are listened
+// before the change, by default the ones owned so far.
+func (m *Membership) refresh(replicas []*model.Replica, holding func(string) bool) (changed bool) {
+	ids := make([]string, 0, len(replicas)+1)
+	for _, replica := range replicas {
+		ids = append(ids, replica.ID)
+	}
+	// this replica is always part of the ring, even if its own heartbeat is late
+	if !slices.Contains(ids, m.instanceID) {
+		ids = append(ids, m.instanceID)
+	}
+	ring := NewRing(ids)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ring.Equal(m.ring) {
+		return false
+	}
+	glog.Infof("Vault replicas changed, %s is one of %v", m.instanceID, ring.Replicas())
+	if holding == nil {
+		previousRing, previousHeld := m.ring, m.held
+		holding = func(tenantID string) bool {
+			return m.owns(previousRing, previousHeld, tenantID)
+		}
+	}
+	m.ring = ring
+	m.held = holding
+	m.handoffStarted = time.Now().UTC()
+	return true
+}
+
+// apply records that the lost tenants of the current ring are released
+func (m *Membership) apply(ctx context.Context) error {
+	m.mu.RLock()
+	key := m.ring.Key()
+	m.mu.RUnlock()
+
+	m.applied = key
+	return m.db.UpdateReplica(ctx, m.instanceID, key)
+}
+
+// completeHandoff ends the handoff when all the other live replicas have applied the current ring
+func (m *Membership) completeHandoff(replicas []*model.Replica, interval time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.held == nil {
+		return false
+	}
+	pending := make([]string, 0)
+	for _, replica := range replicas {
+		if replica.ID != m.instanceID && replica.Ring != m.ring.Key() {
+			pending = append(pending, replica.ID)
+		}
+	}
+	if len(pending) > 0 {
+		if time.Since(m.handoffStarted) < missedHeartbeats*interval {
+			return false
+		}
+		glog.Warningf("Replicas %v have not applied the ring, completing the handoff anyway", pending)
+	}
+	utils.LogMed().Infof("Tenant handoff complete for %s", m.instanceID)
+	m.held = nil
+	return true
+}
+
+// Run sends the heartbeat of this replica with the interval until the context is done.
+// The replica leaves the ring on exit.
+func (m *Membership) Run(ctx context.Context, interval time.Duration) {
+	utils.LogMed().Infof("Start replica heartbeat for %s with interval %v", m.instanceID, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			m.leave()
+			utils.LogMed().Infoln("Stop replica heartbeat")
+			return
+		case <-ticker.C:
+		}
+		m.heartbeat(ctx, interval)
+	}
+}
+
+func (m *Membership) heartbeat(ctx context.Context, interval time.Duration) {
+	defer err2.Catch(err2.Err(func(err error) {
+		glog.Errorf("Replica heartbeat failed for %s: %s", m.instanceID, err.Error())
+	}))
+
+	// allow some clock skew between the replicas and the database
+	now := time.Now().UTC()
+	since := m.synced.Add(-interval)
+
+	try.To(m.db.UpdateReplica(ctx, m.instanceID, m.applied))
+	replicas := try.To1(m.db.GetReplicas(ctx, missedHeartbeats*interval))
+	switch {
+	case m.refresh(replicas, nil):
+		// lost tenants are released, the gained ones are opened when the handoff is complete
+		m.agency.Rebalance(m.Owns, try.To1(m.agents.ListAgents()))
+		try.To(m.apply(ctx))
+	case m.completeHandoff(replicas, interval):
+		m.agency.Rebalance(m.Owns, try.To1(m.agents.ListAgents()))
+	default:
+		m.resumeAccessed(try.To1(m.agents.FetchAccessedAgents(since)))
+	}
+	m.synced = now
+}
+
+// resumeAccessed makes sure that the owned tenants that have accessed
+// the vault through the other replicas are listened
+func (m *Membership) resumeAccessed(agents []*agency.Agent) {
+	for _, a := range agents {
+		if !m.Owns(a.TenantID) {
+			continue
+		}
+		if err := m.agency.AddAgent(a); err != nil {
+			glog.Warningf("Unable to resume listener for tenant %s: %s", a.TenantID, err.Error())
+		}
+	}
+}
+
+// leave removes this replica so that the others take over its tenants without waiting for the timeout
+func (m *Membership) leave() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := m.db.RemoveReplica(ctx, m.instanceID); err != nil {
+		glog.Warningf("Unable to remove replica %s: %s", m.instanceID, err.Error())
+	}
+}
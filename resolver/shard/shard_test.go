@@ -0,0 +1,167 @@
+package shard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/mock"
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/paginator"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	"github.com/golang/mock/gomock"
+)
+
+// replicaDB imitates the replica registry of the database, other store methods are not used
+type replicaDB struct {
+	store.DB
+	mu       sync.Mutex
+	replicas map[string]string
+	agents   []*model.Agent
+}
+
+func (d *replicaDB) UpdateReplica(_ context.Context, id, ring string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.replicas[id] = ring
+	return nil
+}
+
+func (d *replicaDB) GetReplicas(_ context.Context, _ time.Duration) ([]*model.Replica, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	replicas := make([]*model.Replica, 0)
+	for id, ring := range d.replicas {
+		replicas = append(replicas, &model.Replica{ID: id, Ring: ring})
+	}
+	return replicas, nil
+}
+
+func (d *replicaDB) RemoveReplica(_ context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.replicas, id)
+	return nil
+}
+
+func (d *replicaDB) GetListenerAgents(_ *paginator.BatchInfo) (*model.Agents, error) {
+	return &model.Agents{Agents: d.agents}, nil
+}
+
+func (d *replicaDB) GetAccessedAgents(_ time.Time) ([]*model.Agent, error) {
+	return d.agents, nil
+}
+
+func testAgents(count int) []*model.Agent {
+	agents := make([]*model.Agent, count)
+	for i := range agents {
+		agents[i] = &model.Agent{Base: model.Base{ID: fmt.Sprintf("tenant-%d", i)}, RawJWT: "jwt"}
+	}
+	return agents
+}
+
+func TestMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := &replicaDB{replicas: map[string]string{}, agents: testAgents(20)}
+	agencyMock := mock.NewMockAgency(ctrl)
+
+	first := NewMembership(db, agencyMock, agent.NewResolver(db, agencyMock, ""), "vault-a")
+	ctx := context.Background()
+	if err := first.Join(ctx, time.Second); err != nil {
+		t.Fatalf("Unexpected error when joining %s", err)
+	}
+	for _, a := range db.agents {
+		if !first.Owns(a.ID) {
+			t.Errorf("Single replica should own all tenants, %s not owned", a.ID)
+		}
+	}
+
+	// other replica joins
+	second := NewMembership(db, agencyMock, agent.NewResolver(db, agencyMock, ""), "vault-b")
+	if err := second.Join(ctx, time.Second); err != nil {
+		t.Fatalf("Unexpected error when joining %s", err)
+	}
+	var rebalanced []*agency.Agent
+	agencyMock.EXPECT().Rebalance(gomock.Any(), gomock.Any()).Do(func(_ func(string) bool, agents []*agency.Agent) {
+		rebalanced = agents
+	})
+	first.heartbeat(ctx, time.Second)
+
+	if len(rebalanced) != len(db.agents) {
+		t.Errorf("Mismatch in rebalanced agents, expected: %d got: %d", len(db.agents), len(rebalanced))
+	}
+	// new replica opens its tenants only after the first one has released them
+	for _, a := range db.agents {
+		if second.Owns(a.ID) {
+			t.Errorf("Tenant %s should not be owned before the handoff is complete", a.ID)
+		}
+	}
+	agencyMock.EXPECT().Rebalance(gomock.Any(), gomock.Any()).Times(2)
+	second.heartbeat(ctx, time.Second)
+	first.heartbeat(ctx, time.Second)
+
+	owned := 0
+	for _, a := range db.agents {
+		if first.Owns(a.ID) == second.Owns(a.ID) {
+			t.Errorf("Tenant %s should be owned by exactly one replica", a.ID)
+		}
+		if first.Owns(a.ID) {
+			owned++
+		}
+	}
+
+	// no changes, accessed owned tenants are resumed
+	agencyMock.EXPECT().AddAgent(gomock.Any()).Times(owned)
+	first.heartbeat(ctx, time.Second)
+
+	// other replica leaves, all tenants are taken over once no other replica is left to release them
+	second.leave()
+	agencyMock.EXPECT().Rebalance(gomock.Any(), gomock.Any()).Times(2)
+	first.heartbeat(ctx, time.Second)
+	first.heartbeat(ctx, time.Second)
+	for _, a := range db.agents {
+		if !first.Owns(a.ID) {
+			t.Errorf("Remaining replica should own all tenants, %s not owned", a.ID)
+		}
+	}
+}
+
+func TestHandoffTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// other replica never applies the ring
+	db := &replicaDB{replicas: map[string]string{"vault-x": ""}, agents: testAgents(20)}
+	agencyMock := mock.NewMockAgency(ctrl)
+
+	m := NewMembership(db, agencyMock, agent.NewResolver(db, agencyMock, ""), "vault-a")
+	ctx := context.Background()
+	interval := time.Millisecond
+	if err := m.Join(ctx, interval); err != nil {
+		t.Fatalf("Unexpected error when joining %s", err)
+	}
+	for _, a := range db.agents {
+		if m.Owns(a.ID) {
+			t.Errorf("Tenant %s should not be owned before the handoff is complete", a.ID)
+		}
+	}
+
+	time.Sleep(missedHeartbeats * interval)
+	agencyMock.EXPECT().Rebalance(gomock.Any(), gomock.Any())
+	m.heartbeat(ctx, interval)
+
+	owned := 0
+	for _, a := range db.agents {
+		if m.Owns(a.ID) {
+			owned++
+		}
+	}
+	if owned == 0 {
+		t.Errorf("Expecting tenants to be owned after the handoff timeout")
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/golang/mock/gomock"
 )
 
+const testAdminID = "test-admin"
+
 var (
 	r                *resolver.Resolver
 	testConnectionID string
@@ -32,11 +34,12 @@ var (
 	totalCount       = 5
 
 	config = &utils.Configuration{
-		DBHost:           "localhost",
-		DBPassword:       os.Getenv("FAV_DB_PASSWORD"),
-		DBPort:           5433,
-		DBMigrationsPath: "file://../../db/migrations",
-		DBName:           "resolver",
+		DBHost:            "localhost",
+		DBPassword:        os.Getenv("FAV_DB_PASSWORD"),
+		DBPort:            5433,
+		DBMigrationsPath:  "file://../../db/migrations",
+		DBName:            "resolver",
+		DeadLetterAdminID: testAdminID,
 	}
 	resolverDB store.DB
 )
@@ -53,6 +56,10 @@ func testContext() context.Context {
 	return testContextForUser(fake.FakeCloudDID)
 }
 
+func adminContext() context.Context {
+	return testContextForUser(testAdminID)
+}
+
 func setup() {
 	utils.SetLogDefaults()
 
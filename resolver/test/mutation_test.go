@@ -1,11 +1,17 @@
 package test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/db/fake"
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 )
 
 const (
@@ -38,7 +44,7 @@ func TestInvite(t *testing.T) {
 
 	m.
 		EXPECT().
-		Invite(gomock.Any()).Return(&data, nil)
+		Invite(gomock.Any(), gomock.Any()).Return(&data, nil)
 
 	resp, err := r.Mutation().Invite(testContextForUser(user))
 	if err != nil {
@@ -55,7 +61,7 @@ func TestConnect(t *testing.T) {
 
 	m.
 		EXPECT().
-		Connect(gomock.Any(), gomock.Any()).
+		Connect(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return("d679e4c6-b8db-4c39-99ca-783034b51bd4", nil)
 
 	resp, err := r.Mutation().Connect(testContextForUser(user), model.ConnectInput{Invitation: testInvitation})
@@ -72,9 +78,116 @@ func TestSendMessage(t *testing.T) {
 
 	m.
 		EXPECT().
-		SendMessage(gomock.Any(), gomock.Any(), gomock.Any())
+		SendMessage(gomock.Any(), gomock.Any(), gomock.Eq(testConnectionID), gomock.Any()).
+		Return(uuid.New().String(), nil)
 
-	resp, err := r.Mutation().SendMessage(testContext(), model.MessageInput{})
+	resp, err := r.Mutation().SendMessage(testContext(), model.MessageInput{ConnectionID: testConnectionID, Message: "message"})
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+}
+
+func TestSendCredentialOffer(t *testing.T) {
+	m := beforeEach(t)
+
+	m.
+		EXPECT().
+		SendCredentialOffer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	resp, err := r.Mutation().SendCredentialOffer(testContext(), model.CredentialOfferInput{
+		Attributes: []*model.CredentialValueInput{{Name: "name", Value: "value"}},
+	})
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+}
+
+func TestSendProofRequest(t *testing.T) {
+	m := beforeEach(t)
+
+	issuerDID := "issuerDID"
+	credDefID := "credDefID"
+
+	m.
+		EXPECT().
+		SendProofRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(uuid.New().String(), nil)
+
+	resp, err := r.Mutation().SendProofRequest(testContext(), model.ProofRequestInput{
+		ConnectionID: testConnectionID,
+		Attributes: []*model.ProofRequestAttribute{{
+			Name:         "name",
+			Restrictions: []*model.ProofRestrictionInput{{IssuerDid: &issuerDID}, {CredDefID: &credDefID}},
+		}},
+		Predicates: []*model.ProofRequestPredicate{{Name: "age", Operator: ">=", Value: 18, CredDefID: "credDefID"}},
+	})
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+}
+
+func TestSendProofRequestInvalidPredicate(t *testing.T) {
+	beforeEach(t)
+
+	_, err := r.Mutation().SendProofRequest(testContext(), model.ProofRequestInput{
+		ConnectionID: testConnectionID,
+		Predicates:   []*model.ProofRequestPredicate{{Name: "age", Operator: "==", Value: 18, CredDefID: "credDefID"}},
+	})
+	if err == nil {
+		t.Errorf("Expecting error for invalid predicate operator")
+	}
+}
+
+func TestSendProofRequestEmptyRestriction(t *testing.T) {
+	beforeEach(t)
+
+	_, err := r.Mutation().SendProofRequest(testContext(), model.ProofRequestInput{
+		ConnectionID: testConnectionID,
+		Attributes: []*model.ProofRequestAttribute{{
+			Name:         "name",
+			Restrictions: []*model.ProofRestrictionInput{{}},
+		}},
+	})
+	if err == nil {
+		t.Errorf("Expecting error for empty restriction")
+	}
+}
+
+func TestSendProofRequestConflictingRestriction(t *testing.T) {
+	beforeEach(t)
+
+	credDefID := "credDefID"
+	otherCredDefID := "otherCredDefID"
+	_, err := r.Mutation().SendProofRequest(testContext(), model.ProofRequestInput{
+		ConnectionID: testConnectionID,
+		Attributes: []*model.ProofRequestAttribute{{
+			Name:         "name",
+			CredDefID:    &credDefID,
+			Restrictions: []*model.ProofRestrictionInput{{CredDefID: &otherCredDefID}},
+		}},
+	})
+	if err == nil {
+		t.Errorf("Expecting error for conflicting restriction")
+	}
+}
+
+func TestPingConnection(t *testing.T) {
+	m := beforeEach(t)
+
+	m.
+		EXPECT().
+		PingConnection(gomock.Any(), gomock.Any(), gomock.Any())
+
+	resp, err := r.Mutation().PingConnection(testContext(), testConnectionID)
 	if err != nil {
 		t.Errorf("Received unexpected error %s", err)
 	}
@@ -88,7 +201,7 @@ func TestResume(t *testing.T) {
 
 	m.
 		EXPECT().
-		ResumeCredentialOffer(gomock.Any(), gomock.Any(), gomock.Any())
+		ResumeCredentialOffer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
 	resp, err := r.Mutation().Resume(testContext(), model.ResumeJobInput{ID: testJobID})
 	if err != nil {
@@ -98,3 +211,400 @@ func TestResume(t *testing.T) {
 		t.Errorf("Expecting result, received %v", resp)
 	}
 }
+
+func TestSetPolicy(t *testing.T) {
+	beforeEach(t)
+
+	credDefID := "credDefID"
+	policy, err := r.Mutation().SetPolicy(testContext(), model.PolicyInput{
+		Protocol:   model.ProtocolTypeCredential,
+		Action:     model.PolicyActionAccept,
+		CredDefID:  &credDefID,
+		Attributes: []string{"name"},
+	})
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+	if policy.CredDefID != credDefID || policy.Action != model.PolicyActionAccept {
+		t.Errorf("Unexpected policy %v", policy)
+	}
+
+	policies, err := r.Query().Policies(testContext())
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	found := false
+	for _, p := range policies {
+		found = found || p.ID == policy.ID
+	}
+	if !found {
+		t.Errorf("Added policy not found")
+	}
+
+	resp, err := r.Mutation().RemovePolicy(testContext(), policy.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+}
+
+func TestSetPolicyInvalidProtocol(t *testing.T) {
+	beforeEach(t)
+
+	_, err := r.Mutation().SetPolicy(testContext(), model.PolicyInput{
+		Protocol: model.ProtocolTypeBasicMessage,
+		Action:   model.PolicyActionAccept,
+	})
+	if err == nil {
+		t.Errorf("Expecting error for unsupported protocol")
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	m := beforeEach(t)
+
+	db := r.Store()
+	agentID := fake.FakeCloudDID
+	a, err := db.GetAgent(nil, &agentID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+	job := fake.AddProofJobs(db, a.ID, testConnectionID, testProofID, 1, model.JobStatusWaiting)[0]
+
+	m.
+		EXPECT().
+		CancelJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(model.ProtocolTypeProof))
+
+	resp, err := r.Mutation().CancelJob(testContext(), job.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+
+	got, err := db.GetJob(job.ID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Status != model.JobStatusComplete || got.Result != model.JobResultCancelled {
+		t.Errorf("Job not cancelled, status %s result %s", got.Status, got.Result)
+	}
+
+	proof, err := db.GetProof(testProofID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if proof.Archived.IsZero() {
+		t.Errorf("Proof not archived on cancel")
+	}
+}
+
+func TestCancelJobReleaseFailure(t *testing.T) {
+	m := beforeEach(t)
+
+	db := r.Store()
+	agentID := fake.FakeCloudDID
+	a, err := db.GetAgent(nil, &agentID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+	job := fake.AddProofJobs(db, a.ID, testConnectionID, testProofID, 1, model.JobStatusWaiting)[0]
+
+	m.
+		EXPECT().
+		CancelJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(model.ProtocolTypeProof)).
+		Return(errors.New("agency unavailable"))
+
+	if _, err := r.Mutation().CancelJob(testContext(), job.ID); err == nil {
+		t.Errorf("Expecting error when protocol release fails")
+	}
+
+	got, err := db.GetJob(job.ID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Status != model.JobStatusWaiting {
+		t.Errorf("Job status changed on failed cancel, status %s result %s", got.Status, got.Result)
+	}
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	m := beforeEach(t)
+
+	m.
+		EXPECT().
+		SendMessage(gomock.Any(), gomock.Any(), gomock.Eq(testConnectionID), gomock.Any()).
+		Return("", errors.New("agency unavailable"))
+
+	if _, err := r.Mutation().SendMessage(testContext(), model.MessageInput{ConnectionID: testConnectionID, Message: "message"}); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+
+	db := r.Store()
+	agentID := fake.FakeCloudDID
+	a, err := db.GetAgent(nil, &agentID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+	jobs, err := db.GetJobs(&paginator.BatchInfo{Count: 1, Tail: true}, a.ID, &testConnectionID, nil)
+	if err != nil || len(jobs.Jobs) != 1 {
+		t.Errorf("Queued job not found %v", err)
+		return
+	}
+	job := jobs.Jobs[0]
+
+	// queued message has no protocol at agency to release
+	if _, err = r.Mutation().CancelJob(testContext(), job.ID); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+
+	got, err := db.GetJob(job.ID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Result != model.JobResultCancelled {
+		t.Errorf("Queued job not cancelled, status %s result %s", got.Status, got.Result)
+	}
+	item, err := db.GetOutboxItem(job.ID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if item.IsPending() {
+		t.Errorf("Outbox item of cancelled job is still pending")
+	}
+}
+
+func TestCancelCompletedJob(t *testing.T) {
+	beforeEach(t)
+
+	_, err := r.Mutation().CancelJob(testContext(), testJobID)
+	if err == nil {
+		t.Errorf("Expecting error for completed job")
+	}
+}
+
+func TestSendMessageDeliveryFailure(t *testing.T) {
+	m := beforeEach(t)
+
+	m.
+		EXPECT().
+		SendMessage(gomock.Any(), gomock.Any(), gomock.Eq(testConnectionID), gomock.Any()).
+		Return("", errors.New("agency unavailable"))
+
+	resp, err := r.Mutation().SendMessage(testContext(), model.MessageInput{ConnectionID: testConnectionID, Message: "message"})
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+
+	db := r.Store()
+	agentID := fake.FakeCloudDID
+	a, err := db.GetAgent(nil, &agentID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+	jobs, err := db.GetJobs(&paginator.BatchInfo{Count: 1, Tail: true}, a.ID, &testConnectionID, nil)
+	if err != nil || len(jobs.Jobs) != 1 {
+		t.Errorf("Queued job not found %v", err)
+		return
+	}
+	job := jobs.Jobs[0]
+	if job.Status != model.JobStatusWaiting || job.Attempts != 1 {
+		t.Errorf("Unexpected job state %s with %d attempts", job.Status, job.Attempts)
+	}
+
+	// fail job to be able to retry it manually
+	job.Status = model.JobStatusComplete
+	job.Result = model.JobResultFailure
+	if _, err = db.UpdateJob(job); err != nil {
+		t.Errorf("Received unexpected error %s", err)
+		return
+	}
+
+	m.
+		EXPECT().
+		SendMessage(gomock.Any(), gomock.Any(), gomock.Eq(testConnectionID), gomock.Any()).
+		Return(uuid.New().String(), nil)
+
+	resp, err = r.Mutation().RetryJob(testContext(), job.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+
+	got, err := db.GetJob(job.ID, a.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Result != model.JobResultSuccess || got.Attempts != 2 {
+		t.Errorf("Unexpected job result %s with %d attempts", got.Result, got.Attempts)
+	}
+}
+
+func TestRetryNonFailedJob(t *testing.T) {
+	beforeEach(t)
+
+	_, err := r.Mutation().RetryJob(testContext(), testJobID)
+	if err == nil {
+		t.Errorf("Expecting error for job that has not failed")
+	}
+}
+
+func addTestDeadLetter(t *testing.T) *dbModel.DeadLetter {
+	db := r.Store()
+	agentID := fake.FakeCloudDID
+	a, err := db.GetAgent(nil, &agentID)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	letter, err := db.AddDeadLetter(&dbModel.DeadLetter{
+		Base:           dbModel.Base{TenantID: a.ID},
+		ProtocolID:     uuid.New().String(),
+		ProtocolType:   model.ProtocolTypeCredential,
+		Notification:   []byte(`{}`),
+		ProtocolStatus: []byte(`{}`),
+		LastError:      "failure",
+	})
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	return letter
+}
+
+func TestRetryDeadLetter(t *testing.T) {
+	m := beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+
+	letters, err := r.Query().DeadLetters(adminContext())
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	found := false
+	for _, l := range letters {
+		found = found || l.ID == letter.ID
+	}
+	if !found {
+		t.Errorf("Added dead letter not found")
+	}
+
+	m.
+		EXPECT().
+		ProcessDeadLetter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	resp, err := r.Mutation().RetryDeadLetter(adminContext(), letter.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+
+	if _, err := r.Store().GetDeadLetter(letter.ID); err == nil {
+		t.Errorf("Processed dead letter %s still exists", letter.ID)
+	}
+}
+
+func TestRetryDeadLetterFailure(t *testing.T) {
+	m := beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+
+	m.
+		EXPECT().
+		ProcessDeadLetter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("agency failure"))
+
+	if _, err := r.Mutation().RetryDeadLetter(adminContext(), letter.ID); err == nil {
+		t.Errorf("Expecting error for failed retry")
+	}
+
+	got, err := r.Store().GetDeadLetter(letter.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Attempts != 1 || got.LastError != "agency failure" {
+		t.Errorf("Dead letter not updated on failure %+v", got)
+	}
+}
+
+func TestRetryDeadLetterUnavailable(t *testing.T) {
+	m := beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+
+	m.
+		EXPECT().
+		ProcessDeadLetter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(agency.ErrUnavailable)
+
+	if _, err := r.Mutation().RetryDeadLetter(adminContext(), letter.ID); err == nil {
+		t.Errorf("Expecting error for failed retry")
+	}
+
+	got, err := r.Store().GetDeadLetter(letter.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	} else if got.Attempts != 0 {
+		t.Errorf("Dead letter attempt counted when agency was unavailable %+v", got)
+	}
+}
+
+func TestDiscardDeadLetter(t *testing.T) {
+	beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+
+	resp, err := r.Mutation().DiscardDeadLetter(adminContext(), letter.ID)
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if resp == nil {
+		t.Errorf("Expecting result, received %v", resp)
+	}
+
+	if _, err := r.Store().GetDeadLetter(letter.ID); err == nil {
+		t.Errorf("Discarded dead letter %s still exists", letter.ID)
+	}
+}
+
+func TestDiscardClaimedDeadLetter(t *testing.T) {
+	beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+	if _, err := r.Store().ClaimDeadLetter(letter.ID, time.Now().UTC().Add(time.Minute)); err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	if _, err := r.Mutation().DiscardDeadLetter(adminContext(), letter.ID); err == nil {
+		t.Errorf("Expecting error when discarding dead letter being retried")
+	}
+	if _, err := r.Store().GetDeadLetter(letter.ID); err != nil {
+		t.Errorf("Dead letter %s being retried was removed", letter.ID)
+	}
+}
+
+func TestDeadLettersRequireAdmin(t *testing.T) {
+	beforeEach(t)
+
+	letter := addTestDeadLetter(t)
+
+	if _, err := r.Query().DeadLetters(testContext()); err == nil {
+		t.Errorf("Expecting error when listing dead letters as tenant")
+	}
+	if _, err := r.Mutation().RetryDeadLetter(testContext(), letter.ID); err == nil {
+		t.Errorf("Expecting error when retrying dead letter as tenant")
+	}
+	if _, err := r.Mutation().DiscardDeadLetter(testContext(), letter.ID); err == nil {
+		t.Errorf("Expecting error when discarding dead letter as tenant")
+	}
+	if _, err := r.Store().GetDeadLetter(letter.ID); err != nil {
+		t.Errorf("Dead letter %s removed by tenant", letter.ID)
+	}
+}
@@ -3,7 +3,15 @@ package test
 import (
 	"context"
 	"encoding/base64"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/agency/mock"
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
+	"github.com/golang/mock/gomock"
 )
 
 func TestPaginationErrorsGetConnections(t *testing.T) {
@@ -202,3 +210,43 @@ func TestGetEndpoint(t *testing.T) {
 		t.Errorf("Expecting valid result, received %v", e)
 	}
 }
+
+func TestGetSystemStatus(t *testing.T) {
+	m := beforeEach(t)
+
+	retryAt := time.Now().Add(time.Minute)
+	m.EXPECT().Health().Return(&agency.Health{
+		Breaker:  model.BreakerStateOpen,
+		Failures: 5,
+		RetryAt:  retryAt,
+	})
+
+	s, err := r.Query().SystemStatus(testContext())
+	if err != nil {
+		t.Errorf("Received unexpected error %s", err)
+	}
+	if s.Agency.Available || !s.Agency.Degraded || s.Agency.Breaker != model.BreakerStateOpen || s.Agency.Failures != 5 {
+		t.Errorf("Mismatch in agency status %+v", s.Agency)
+	}
+	if s.Agency.RetryAtMs == nil || *s.Agency.RetryAtMs != strconv.FormatInt(retryAt.UnixMilli(), 10) {
+		t.Errorf("Mismatch in retry time, expected %d got %v", retryAt.UnixMilli(), s.Agency.RetryAtMs)
+	}
+	if s.HookLeader == nil || s.HookLeader.Instance != config.InstanceID || s.HookLeader.IsLeader {
+		t.Errorf("Mismatch in hook leader status %+v", s.HookLeader)
+	}
+}
+
+func TestGetAgentTouchesAgencyOnce(t *testing.T) {
+	beforeEach(t)
+
+	m := mock.NewMockAgency(gomock.NewController(t))
+	m.EXPECT().AddAgent(gomock.Any()).Times(1)
+
+	// repeated requests within the touch interval do not reach the agency
+	resolver := agent.NewResolver(r.Store(), m, "")
+	for i := 0; i < 3; i++ {
+		if _, err := resolver.GetAgent(testContext()); err != nil {
+			t.Errorf("Received unexpected error %s", err)
+		}
+	}
+}
@@ -1,13 +1,15 @@
 package test
 
 import (
+	"context"
 	"testing"
+	"time"
 )
 
 func TestSubscribeEventAdded(t *testing.T) {
 	beforeEach(t)
 
-	channel, err := r.Subscription().EventAdded(testContext())
+	channel, err := r.Subscription().EventAdded(testContext(), nil)
 	if err != nil {
 		t.Errorf("Received unexpected error %s", err)
 	}
@@ -16,4 +18,42 @@ func TestSubscribeEventAdded(t *testing.T) {
 	}
 }
 
-// TODO: add test for sending events
+func TestSubscribeEventAddedAfter(t *testing.T) {
+	beforeEach(t)
+
+	last := totalCount
+	stored, err := r.Query().Events(testContext(), nil, nil, nil, &last)
+	if err != nil || len(stored.Edges) != last {
+		t.Fatalf("Received unexpected result %v %v", stored, err)
+	}
+	after := stored.Edges[0].Cursor
+
+	ctx, cancel := context.WithCancel(testContext())
+	defer cancel()
+	channel, err := r.Subscription().EventAdded(ctx, &after)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	// stored events after the cursor are replayed once in creation order
+	for _, expected := range stored.Edges[1:] {
+		select {
+		case edge := <-channel:
+			if edge.Node.ID != expected.Node.ID {
+				t.Errorf("Mismatch in replayed event, expected: %s got: %s", expected.Node.ID, edge.Node.ID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Stored event %s was not replayed", expected.Node.ID)
+		}
+	}
+	select {
+	case edge := <-channel:
+		t.Errorf("Unexpected event %s after the replay", edge.Node.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	invalid := "invalid"
+	if _, err = r.Subscription().EventAdded(ctx, &invalid); err == nil {
+		t.Errorf("Expecting error for invalid cursor")
+	}
+}
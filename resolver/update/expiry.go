@@ -0,0 +1,30 @@
+package update
+
+import (
+	"time"
+
+	"github.com/findy-network/findy-agent-vault/db/model"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+)
+
+// JobTimeouts define per protocol how long a job can wait for the other party
+type JobTimeouts map[graph.ProtocolType]time.Duration
+
+func NewJobTimeouts(config *utils.Configuration) JobTimeouts {
+	return JobTimeouts{
+		graph.ProtocolTypeConnection: time.Duration(config.JobTimeoutConnection) * time.Second,
+		graph.ProtocolTypeCredential: time.Duration(config.JobTimeoutCredential) * time.Second,
+		graph.ProtocolTypeProof:      time.Duration(config.JobTimeoutProof) * time.Second,
+	}
+}
+
+// expires resolves the expiry time for a job waiting for the other party,
+// the timer is restarted on each job update
+func (t JobTimeouts) expires(j *model.Job) time.Time {
+	timeout := t[j.ProtocolType]
+	if j.Status != graph.JobStatusWaiting || timeout <= 0 {
+		return time.Time{}
+	}
+	return utils.CurrentTime().Add(timeout)
+}
@@ -0,0 +1,31 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+)
+
+func TestJobExpires(t *testing.T) {
+	timeouts := JobTimeouts{model.ProtocolTypeProof: time.Minute}
+
+	tests := []struct {
+		name    string
+		job     *dbModel.Job
+		expires bool
+	}{
+		{"waiting", &dbModel.Job{ProtocolType: model.ProtocolTypeProof, Status: model.JobStatusWaiting}, true},
+		{"pending", &dbModel.Job{ProtocolType: model.ProtocolTypeProof, Status: model.JobStatusPending}, false},
+		{"no timeout", &dbModel.Job{ProtocolType: model.ProtocolTypeCredential, Status: model.JobStatusWaiting}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expires := timeouts.expires(tc.job)
+			if expires.IsZero() == tc.expires {
+				t.Errorf("Expiry mismatch for %s job, expected expiry: %v got: %v", tc.name, tc.expires, expires)
+			}
+		})
+	}
+}
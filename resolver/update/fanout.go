@@ -0,0 +1,121 @@
+package update
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+// recentEventCount is the number of dispatched event IDs remembered for deduplication
+const recentEventCount = 1024
+
+var (
+	publishedEvents = expvar.NewInt("event_fanout_published")
+	receivedEvents  = expvar.NewInt("event_fanout_received")
+	duplicateEvents = expvar.NewInt("event_fanout_duplicates")
+)
+
+// Events are published through the database to all vault replicas, so that
+// the subscriptions get the events regardless of the replica the client is connected to.
+
+// recentEvents remembers the latest dispatched events so that
+// an event received more than once is delivered to the subscriptions only once
+type recentEvents struct {
+	sync.Mutex
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+func newRecentEvents(size int) *recentEvents {
+	return &recentEvents{
+		ids:   make(map[string]struct{}, size),
+		order: make([]string, size),
+	}
+}
+
+// add reports false if the event was already seen
+func (r *recentEvents) add(id string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.ids[id]; ok {
+		return false
+	}
+	// forget the oldest event
+	if oldest := r.order[r.next]; oldest != "" {
+		delete(r.ids, oldest)
+	}
+	r.order[r.next] = id
+	r.next = (r.next + 1) % len(r.order)
+	r.ids[id] = struct{}{}
+	return true
+}
+
+func (r *Updater) dispatch(tenantID string, event *dbModel.Event) {
+	if !r.recentEvents.add(event.ID) {
+		utils.LogLow().Infof("Skipping duplicate event %s for tenant %s", event.ID, tenantID)
+		duplicateEvents.Add(1)
+		return
+	}
+	r.eventSubscribers.notify(tenantID, event)
+}
+
+func (r *Updater) publish(tenantID string, event *dbModel.Event) {
+	if r.origin == "" {
+		return
+	}
+
+	err := r.db.PublishEvent(context.Background(), &dbModel.PublishedEvent{
+		ID:       event.ID,
+		TenantID: tenantID,
+		Origin:   r.origin,
+	})
+	if err != nil {
+		glog.Warningf("Unable to publish event %s to other replicas: %s", event.ID, err.Error())
+		return
+	}
+	publishedEvents.Add(1)
+}
+
+// Run dispatches the events added by the other replicas to the subscriptions of this replica
+// until the context is done. The database connection is checked with the ping interval.
+func (r *Updater) Run(ctx context.Context, pingInterval time.Duration) {
+	utils.LogMed().Infof("Start event listener with ping interval %v", pingInterval)
+
+	for ctx.Err() == nil {
+		events, err := r.db.ListenEvents(ctx, pingInterval)
+		if err != nil {
+			glog.Errorf("Unable to listen to events, retrying after %v: %s", pingInterval, err.Error())
+			select {
+			case <-ctx.Done():
+			case <-time.After(pingInterval):
+			}
+			continue
+		}
+		for event := range events {
+			r.receive(event)
+		}
+	}
+	utils.LogMed().Infoln("Stop event listener")
+}
+
+func (r *Updater) receive(published *dbModel.PublishedEvent) {
+	// own events are dispatched already when added
+	if published.Origin == r.origin || !r.eventSubscribers.has(published.TenantID) {
+		return
+	}
+	receivedEvents.Add(1)
+
+	event, err := r.db.GetEvent(published.ID, published.TenantID)
+	if err != nil {
+		glog.Errorf("Unable to fetch published event %s: %s", published.ID, err.Error())
+		return
+	}
+	r.dispatch(published.TenantID, event)
+}
@@ -0,0 +1,125 @@
+package update
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+)
+
+// eventDB imitates the event publishing of the database, other store methods are not used
+type eventDB struct {
+	store.DB
+	mu        sync.Mutex
+	events    map[string]*dbModel.Event
+	published chan *dbModel.PublishedEvent
+}
+
+func (d *eventDB) AddEvent(e *dbModel.Event) (*dbModel.Event, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	event := *e
+	event.ID = "event-" + e.Description
+	d.events[event.ID] = &event
+	return &event, nil
+}
+
+func (d *eventDB) GetEvent(id, _ string) (*dbModel.Event, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.events[id], nil
+}
+
+func (d *eventDB) PublishEvent(_ context.Context, event *dbModel.PublishedEvent) error {
+	d.published <- event
+	return nil
+}
+
+func (d *eventDB) ListenEvents(ctx context.Context, _ time.Duration) (<-chan *dbModel.PublishedEvent, error) {
+	ch := make(chan *dbModel.PublishedEvent)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-d.published:
+				ch <- event
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func TestRecentEvents(t *testing.T) {
+	recent := newRecentEvents(2)
+	if !recent.add("1") || !recent.add("2") {
+		t.Errorf("New events should be added")
+	}
+	if recent.add("1") {
+		t.Errorf("Duplicate event should be detected")
+	}
+	// oldest is forgotten when the buffer is full
+	if !recent.add("3") || !recent.add("1") || recent.add("3") {
+		t.Errorf("Mismatch in recent events %v", recent.order)
+	}
+}
+
+func TestEventFanOut(t *testing.T) {
+	db := &eventDB{events: make(map[string]*dbModel.Event), published: make(chan *dbModel.PublishedEvent, 10)}
+	// replicas share the database
+	local := NewUpdater(db, nil, "replica-a", nil)
+	remote := NewUpdater(db, nil, "replica-b", nil)
+
+	const tenantID = "fanout-tenant"
+	_, localEvents, _ := local.eventSubscribers.add(tenantID, nil, nil)
+	_, remoteEvents, _ := remote.eventSubscribers.add(tenantID, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		remote.Run(ctx, time.Minute)
+		close(done)
+	}()
+
+	if err := local.AddEvent(tenantID, nil, "first"); err != nil {
+		t.Fatalf("Unexpected error when adding event %s", err)
+	}
+	select {
+	case edge := <-localEvents:
+		if edge.Node.ID != "event-first" {
+			t.Errorf("Mismatch in local event %v", edge.Node)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Event not delivered to local subscription")
+	}
+	select {
+	case edge := <-remoteEvents:
+		if edge.Node.ID != "event-first" {
+			t.Errorf("Mismatch in remote event %v", edge.Node)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Event not delivered to other replica")
+	}
+
+	// same event received twice is delivered once
+	duplicates := duplicateEvents.Value()
+	remote.receive(&dbModel.PublishedEvent{ID: "event-first", TenantID: tenantID, Origin: "replica-a"})
+	if duplicateEvents.Value() != duplicates+1 {
+		t.Errorf("Duplicate event should be skipped")
+	}
+	// own events are not dispatched twice
+	received := receivedEvents.Value()
+	local.receive(&dbModel.PublishedEvent{ID: "event-first", TenantID: tenantID, Origin: "replica-a"})
+	if receivedEvents.Value() != received {
+		t.Errorf("Own event should be skipped")
+	}
+
+	cancel()
+	<-done
+}
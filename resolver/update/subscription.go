@@ -2,26 +2,172 @@ package update
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	dbModel "github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/findy-network/findy-agent-vault/utils"
 	"github.com/golang/glog"
 	"github.com/lainio/err2"
 	"github.com/lainio/err2/try"
 )
 
+// subscriptionQueueSize is the max number of events waiting for delivery to a single subscriber
+const subscriptionQueueSize = 32
+
+var errSubscriptionsClosed = errors.New("server is shutting down")
+
+// ErrResyncRequired is reported to a subscriber that was dropped for not keeping up with the events,
+// the client should fetch the missed events before subscribing again
+var ErrResyncRequired = errors.New("subscription dropped, resync required")
+
+var (
+	queuedEvents       = expvar.NewInt("subscription_queued_events")
+	droppedSubscribers = expvar.NewInt("subscription_dropped_subscribers")
+
+	// liveSubscriptions holds the subscriptions by ID for the queue depth metrics
+	liveSubscriptions sync.Map
+)
+
+func init() {
+	expvar.Publish("subscription_queue_depth", expvar.Func(queueDepths))
+}
+
+// queueDepths reports the number of queued events per subscription and the deepest queue
+func queueDepths() any {
+	depths := make(map[string]int)
+	deepest := 0
+	liveSubscriptions.Range(func(key, value any) bool {
+		depth := len(value.(*subscription).queue)
+		depths[key.(string)] = depth
+		deepest = max(deepest, depth)
+		return true
+	})
+	return map[string]any{"max": deepest, "subscriptions": depths}
+}
+
+type dropSignalKey struct{}
+
+// WithDropSignal prepares the operation context for telling if the subscription was dropped
+func WithDropSignal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dropSignalKey{}, new(atomic.Bool))
+}
+
+func dropSignal(ctx context.Context) *atomic.Bool {
+	signal, _ := ctx.Value(dropSignalKey{}).(*atomic.Bool)
+	return signal
+}
+
+// TakeDropped reports once if the subscription of the operation was dropped
+func TakeDropped(ctx context.Context) bool {
+	signal := dropSignal(ctx)
+	return signal != nil && signal.CompareAndSwap(true, false)
+}
+
+// subscription queues the events of a single subscriber. Events are queued without blocking,
+// so that a slow subscriber does not hold up the notifications of the others.
+// Subscriber that can not keep up with its events is dropped: the client receives ErrResyncRequired
+// before the subscription completes and should subscribe again after the last received event.
 type subscription struct {
-	channel  chan *model.EventEdge
 	tenantID string
+	queue    chan *model.EventEdge
+	channel  chan *model.EventEdge
+	done     chan struct{}
+	// replay passes the stored events that are delivered before the live events
+	replay chan []*model.EventEdge
+	// dropped is set when the subscriber is dropped, nil if the transport does not report it
+	dropped *atomic.Bool
+}
+
+func newSubscription(tenantID string, dropped *atomic.Bool) *subscription {
+	s := &subscription{
+		tenantID: tenantID,
+		queue:    make(chan *model.EventEdge, subscriptionQueueSize),
+		channel:  make(chan *model.EventEdge),
+		done:     make(chan struct{}),
+		replay:   make(chan []*model.EventEdge, 1),
+		dropped:  dropped,
+	}
+	go s.deliver()
+	return s
+}
+
+// send queues the event, it fails if the queue is full
+func (s *subscription) send(edge *model.EventEdge) bool {
+	queuedEvents.Add(1)
+	select {
+	case s.queue <- edge:
+		return true
+	default:
+		queuedEvents.Add(-1)
+		return false
+	}
+}
+
+// deliver passes the replayed and the queued events to the subscriber until the subscription is stopped.
+// Live events are queued already during the replay, the events included in the replay are skipped.
+func (s *subscription) deliver() {
+	defer close(s.channel)
+
+	var replayed map[string]struct{}
+	select {
+	case <-s.done:
+		queuedEvents.Add(-int64(len(s.queue)))
+		return
+	case replay := <-s.replay:
+		replayed = make(map[string]struct{}, len(replay))
+		for _, edge := range replay {
+			select {
+			case s.channel <- edge:
+				replayed[edge.Node.ID] = struct{}{}
+			case <-s.done:
+				queuedEvents.Add(-int64(len(s.queue)))
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-s.done:
+			queuedEvents.Add(-int64(len(s.queue)))
+			return
+		case edge := <-s.queue:
+			if _, ok := replayed[edge.Node.ID]; ok {
+				queuedEvents.Add(-1)
+				continue
+			}
+			select {
+			case s.channel <- edge:
+				queuedEvents.Add(-1)
+			case <-s.done:
+				queuedEvents.Add(-int64(len(s.queue)) - 1)
+				return
+			}
+		}
+	}
+}
+
+// start begins the delivery with the replayed events
+func (s *subscription) start(replay []*model.EventEdge) {
+	s.replay <- replay
+}
+
+// stop ends the subscription, no events can be sent after stopping
+func (s *subscription) stop() {
+	close(s.done)
 }
 
 type subscriberRegister struct {
 	*sync.RWMutex
 	subscriptions map[string]*subscription
 	agents        map[string][]string
+	closed        bool
 }
 
 func newSubscriberRegister() *subscriberRegister {
@@ -33,13 +179,21 @@ func newSubscriberRegister() *subscriberRegister {
 }
 
 func (s *subscriberRegister) notify(tenantID string, event *dbModel.Event) {
+	for _, subscriptionID := range s.enqueue(tenantID, event) {
+		s.drop(subscriptionID)
+	}
+}
+
+// enqueue queues the event for the subscribers of the tenant,
+// returns the subscriptions whose queue is full
+func (s *subscriberRegister) enqueue(tenantID string, event *dbModel.Event) (overflown []string) {
 	s.RLock()
 	defer s.RUnlock()
 
 	agentSubscriptions, ok := s.agents[tenantID]
 	if !ok {
 		utils.LogTrace().Infof("Skipping notifications, no subscriptions for %s", tenantID)
-		return
+		return nil
 	}
 
 	for _, subscriptionID := range agentSubscriptions {
@@ -51,24 +205,80 @@ func (s *subscriberRegister) notify(tenantID string, event *dbModel.Event) {
 
 		eventEdge := event.ToEdge()
 		utils.LogMed().Infof("Sending event %v to tenant %s", eventEdge, tenantID)
-		subscription.channel <- eventEdge
+		if !subscription.send(eventEdge) {
+			overflown = append(overflown, subscriptionID)
+		}
 	}
+	return overflown
 }
 
-func (s *subscriberRegister) add(tenantID string) (subscriptionID string, eventChannel <-chan *model.EventEdge) {
+// drop ends the subscription of a subscriber that can not keep up with the events
+func (s *subscriberRegister) drop(subscriptionID string) {
 	s.Lock()
 	defer s.Unlock()
 
+	if s.closed {
+		return
+	}
+	if subscription := s.detach(subscriptionID); subscription != nil {
+		glog.Warningf("Subscription %s dropped for tenant %s, %d events not delivered",
+			subscriptionID, subscription.tenantID, len(subscription.queue))
+		droppedSubscribers.Add(1)
+		// signal is set before the channel is closed so that the transport sees it on completion
+		if subscription.dropped != nil {
+			subscription.dropped.Store(true)
+		}
+		subscription.stop()
+	}
+}
+
+func (s *subscriberRegister) has(tenantID string) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.agents[tenantID]) > 0
+}
+
+// add registers a new subscription for the tenant. Replay is called after the registration
+// so that no events are missed, the replayed events are delivered before the live events.
+func (s *subscriberRegister) add(
+	tenantID string,
+	dropped *atomic.Bool,
+	replay func() ([]*model.EventEdge, error),
+) (subscriptionID string, eventChannel <-chan *model.EventEdge, err error) {
+	var added *subscription
+	if subscriptionID, added, err = s.register(tenantID, dropped); err != nil {
+		return "", nil, err
+	}
+
+	var events []*model.EventEdge
+	if replay != nil {
+		if events, err = replay(); err != nil {
+			s.remove(subscriptionID)
+			return "", nil, err
+		}
+		utils.LogMed().Infof("Replay %d events for subscription %s", len(events), subscriptionID)
+	}
+	added.start(events)
+
+	return subscriptionID, added.channel, nil
+}
+
+func (s *subscriberRegister) register(tenantID string, dropped *atomic.Bool) (subscriptionID string, added *subscription, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return "", nil, errSubscriptionsClosed
+	}
+
 	utils.LogMed().Infof("Add subscription for tenant %s", tenantID)
 
 	const timeLen = 10
 	subscriptionID = tenantID + "-" + strconv.FormatInt(utils.CurrentTimeMs(), timeLen)
-	newSubscription := &subscription{
-		tenantID: tenantID,
-		channel:  make(chan *model.EventEdge, 1),
-	}
-	eventChannel = newSubscription.channel
-	s.subscriptions[subscriptionID] = newSubscription
+	added = newSubscription(tenantID, dropped)
+	s.subscriptions[subscriptionID] = added
+	liveSubscriptions.Store(subscriptionID, added)
 
 	subscriptions, ok := s.agents[tenantID]
 	if !ok {
@@ -84,18 +294,31 @@ func (s *subscriberRegister) remove(subscriptionID string) {
 	s.Lock()
 	defer s.Unlock()
 
+	if s.closed {
+		return
+	}
+
+	if subscription := s.detach(subscriptionID); subscription != nil {
+		subscription.stop()
+		utils.LogMed().Infof("Subscription %s was removed for tenant %s", subscriptionID, subscription.tenantID)
+	}
+}
+
+// detach removes the subscription from the register, returns nil if it was removed already
+func (s *subscriberRegister) detach(subscriptionID string) *subscription {
 	subscription, ok := s.subscriptions[subscriptionID]
 	if !ok {
-		glog.Errorf("Attempted to remove non-existing subscription with ID %s", subscriptionID)
-		return
+		utils.LogLow().Infof("Subscription with ID %s was removed already", subscriptionID)
+		return nil
 	}
 	tenantID := subscription.tenantID
 	delete(s.subscriptions, subscriptionID)
+	liveSubscriptions.Delete(subscriptionID)
 
 	subscriptions, ok := s.agents[tenantID]
 	if !ok {
 		glog.Errorf("Attempted to remove non-existing agent subscription with ID %s", subscriptionID)
-		return
+		return subscription
 	}
 	index := -1
 	for i := 0; i < len(subscriptions); i++ {
@@ -106,7 +329,7 @@ func (s *subscriberRegister) remove(subscriptionID string) {
 	}
 	if index < 0 {
 		glog.Errorf("Subscription with ID %s was not found in agent %s register", subscriptionID, tenantID)
-		return
+		return subscription
 	}
 	if len(subscriptions) > 1 {
 		subscriptions[index] = subscriptions[0]
@@ -115,16 +338,44 @@ func (s *subscriberRegister) remove(subscriptionID string) {
 		subscriptions = make([]string, 0)
 	}
 	s.agents[tenantID] = subscriptions
+	return subscription
+}
+
+// close ends all subscriptions, clients see the subscriptions completed
+func (s *subscriberRegister) close() {
+	s.Lock()
+	defer s.Unlock()
 
-	utils.LogMed().Infof("Subscription %s was removed for tenant %s", subscriptionID, tenantID)
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	utils.LogMed().Infof("Closing %d subscriptions", len(s.subscriptions))
+	for subscriptionID, subscription := range s.subscriptions {
+		liveSubscriptions.Delete(subscriptionID)
+		subscription.stop()
+	}
+	s.subscriptions = make(map[string]*subscription)
+	s.agents = make(map[string][]string)
 }
 
-func (r *Updater) EventAdded(ctx context.Context) (ch <-chan *model.EventEdge, err error) {
+// EventAdded subscribes to the events of the tenant.
+// When the cursor is given, the stored events after the cursor are delivered first.
+func (r *Updater) EventAdded(ctx context.Context, after *string) (ch <-chan *model.EventEdge, err error) {
 	defer err2.Handle(&err)
 
 	tenant := try.To1(r.GetAgent(ctx))
 
-	id, events := r.eventSubscribers.add(tenant.ID)
+	var replay func() ([]*model.EventEdge, error)
+	if after != nil {
+		cursor := try.To1(paginator.ParseCursor(*after, model.Event{}))
+		replay = func() ([]*model.EventEdge, error) {
+			return r.eventsAfter(tenant.ID, cursor)
+		}
+	}
+
+	id, events := try.To2(r.eventSubscribers.add(tenant.ID, dropSignal(ctx), replay))
 	utils.LogMed().Info("subscriptionResolver:EventAdded, id: ", id)
 
 	go func() {
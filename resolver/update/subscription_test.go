@@ -0,0 +1,239 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dbModel "github.com/findy-network/findy-agent-vault/db/model"
+	"github.com/findy-network/findy-agent-vault/db/store"
+	"github.com/findy-network/findy-agent-vault/db/store/mock"
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCloseSubscriptions(t *testing.T) {
+	register := newSubscriberRegister()
+
+	id, events, err := register.add("tenant-id", nil, nil)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	register.close()
+	if _, ok := <-events; ok {
+		t.Errorf("Expected subscription channel to be closed")
+	}
+
+	// removal by the subscription context is a no-op after closing
+	register.remove(id)
+
+	if _, _, err := register.add("tenant-id", nil, nil); !errors.Is(err, errSubscriptionsClosed) {
+		t.Errorf("Expected error when subscribing after close, got %v", err)
+	}
+	register.close()
+}
+
+func TestSubscriptionOverflow(t *testing.T) {
+	register := newSubscriberRegister()
+	defer register.close()
+
+	slowDropped := new(atomic.Bool)
+	_, slow, err := register.add("slow-tenant", slowDropped, nil)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	_, other, err := register.add("other-tenant", nil, nil)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+
+	dropped := droppedSubscribers.Value()
+	// slow subscriber does not read, the sender is not blocked
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*subscriptionQueueSize; i++ {
+			register.notify("slow-tenant", &dbModel.Event{Base: dbModel.Base{ID: strconv.Itoa(i)}})
+		}
+		register.notify("other-tenant", &dbModel.Event{Base: dbModel.Base{ID: "other"}})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Notifications were blocked by slow subscriber")
+	}
+
+	if droppedSubscribers.Value() != dropped+1 {
+		t.Errorf("Mismatch in dropped subscribers, expected: %d got: %d", dropped+1, droppedSubscribers.Value())
+	}
+	if register.has("slow-tenant") {
+		t.Errorf("Slow subscriber should be removed")
+	}
+	if !slowDropped.Load() {
+		t.Errorf("Slow subscriber should be told that it was dropped")
+	}
+	// subscription of the slow subscriber completes
+	for range slow {
+		// drain until closed
+	}
+	if edge := <-other; edge == nil || edge.Node.ID != "other" {
+		t.Errorf("Mismatch in event of other subscriber %v", edge)
+	}
+}
+
+func TestSubscriptionReplay(t *testing.T) {
+	register := newSubscriberRegister()
+	defer register.close()
+
+	replay := func() ([]*model.EventEdge, error) {
+		// events created during the replay are both stored and notified
+		register.notify("tenant-id", &dbModel.Event{Base: dbModel.Base{ID: "stored-2"}})
+		register.notify("tenant-id", &dbModel.Event{Base: dbModel.Base{ID: "live"}})
+		return []*model.EventEdge{
+			{Node: &model.Event{ID: "stored-1"}},
+			{Node: &model.Event{ID: "stored-2"}},
+		}, nil
+	}
+	_, events, err := register.add("tenant-id", nil, replay)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	register.notify("tenant-id", &dbModel.Event{Base: dbModel.Base{ID: "live-2"}})
+
+	for _, expected := range []string{"stored-1", "stored-2", "live", "live-2"} {
+		select {
+		case edge := <-events:
+			if edge.Node.ID != expected {
+				t.Errorf("Mismatch in event, expected: %s got: %s", expected, edge.Node.ID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Event %s was not delivered", expected)
+		}
+	}
+
+	failing := func() ([]*model.EventEdge, error) {
+		return nil, errTooManyEvents
+	}
+	if _, _, err := register.add("other-tenant", nil, failing); !errors.Is(err, errTooManyEvents) {
+		t.Errorf("Expected replay error, got %v", err)
+	}
+	if register.has("other-tenant") {
+		t.Errorf("Subscription should be removed when the replay fails")
+	}
+}
+
+func testEvents(cursors ...uint64) []*dbModel.Event {
+	events := make([]*dbModel.Event, len(cursors))
+	for i, cursor := range cursors {
+		id := "event-" + strconv.FormatUint(cursor, 10)
+		events[i] = &dbModel.Event{Base: dbModel.Base{ID: id, Cursor: cursor}}
+	}
+	return events
+}
+
+func TestEventsAfter(t *testing.T) {
+	m := mock.NewMockDB(gomock.NewController(t))
+	r := &Updater{db: m}
+
+	// pages continue strictly after the last replayed event
+	gomock.InOrder(
+		m.EXPECT().
+			GetEvents(&paginator.BatchInfo{Count: replayBatchSize, After: 7}, "tenant-id", nil).
+			Return(&dbModel.Events{Events: testEvents(8, 9), HasNextPage: true}, nil),
+		m.EXPECT().
+			GetEvents(&paginator.BatchInfo{Count: replayBatchSize, After: 9}, "tenant-id", nil).
+			Return(&dbModel.Events{Events: testEvents(10)}, nil),
+	)
+
+	edges, err := r.eventsAfter("tenant-id", 7)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	expected := []string{"event-8", "event-9", "event-10"}
+	if len(edges) != len(expected) {
+		t.Fatalf("Mismatch in replayed event count, expected: %d got: %d", len(expected), len(edges))
+	}
+	for i, edge := range edges {
+		if edge.Node.ID != expected[i] {
+			t.Errorf("Mismatch in event, expected: %s got: %s", expected[i], edge.Node.ID)
+		}
+	}
+}
+
+func TestEventsAfterNotFound(t *testing.T) {
+	m := mock.NewMockDB(gomock.NewController(t))
+	r := &Updater{db: m}
+
+	m.EXPECT().
+		GetEvents(gomock.Any(), "tenant-id", nil).
+		Return(nil, store.NewError(store.ErrCodeNotFound, "no rows returned"))
+
+	edges, err := r.eventsAfter("tenant-id", 7)
+	if err != nil || len(edges) != 0 {
+		t.Errorf("Expected no replayed events, got %v %v", edges, err)
+	}
+}
+
+func TestEventsAfterTooMany(t *testing.T) {
+	m := mock.NewMockDB(gomock.NewController(t))
+	r := &Updater{db: m}
+
+	m.EXPECT().
+		GetEvents(gomock.Any(), "tenant-id", nil).
+		DoAndReturn(func(info *paginator.BatchInfo, _ string, _ *string) (*dbModel.Events, error) {
+			cursors := make([]uint64, replayBatchSize)
+			for i := range cursors {
+				cursors[i] = info.After + uint64(i) + 1
+			}
+			return &dbModel.Events{Events: testEvents(cursors...), HasNextPage: true}, nil
+		}).
+		Times(maxReplayEvents/replayBatchSize + 1)
+
+	if _, err := r.eventsAfter("tenant-id", 0); !errors.Is(err, errTooManyEvents) {
+		t.Errorf("Expected too many events error, got %v", err)
+	}
+}
+
+func TestQueueDepths(t *testing.T) {
+	register := newSubscriberRegister()
+	defer register.close()
+
+	id, _, err := register.add("depth-tenant", nil, nil)
+	if err != nil {
+		t.Fatalf("Received unexpected error %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		register.notify("depth-tenant", &dbModel.Event{Base: dbModel.Base{ID: strconv.Itoa(i)}})
+	}
+
+	// delivery holds at most one event while waiting for the subscriber
+	depths := queueDepths().(map[string]any)
+	depth := depths["subscriptions"].(map[string]int)[id]
+	if depth < 2 || depths["max"].(int) < depth {
+		t.Errorf("Mismatch in queue depths %v", depths)
+	}
+
+	register.remove(id)
+	if _, ok := queueDepths().(map[string]any)["subscriptions"].(map[string]int)[id]; ok {
+		t.Errorf("Removed subscription %s should not be reported", id)
+	}
+}
+
+func TestTakeDropped(t *testing.T) {
+	if TakeDropped(context.Background()) {
+		t.Errorf("Context without drop signal should not report dropped")
+	}
+	ctx := WithDropSignal(context.Background())
+	if TakeDropped(ctx) {
+		t.Errorf("Subscription should not be dropped yet")
+	}
+	dropSignal(ctx).Store(true)
+	if !TakeDropped(ctx) || TakeDropped(ctx) {
+		t.Errorf("Dropped subscription should be reported exactly once")
+	}
+}
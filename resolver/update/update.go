@@ -1,28 +1,80 @@
 package update
 
 import (
+	"errors"
+
 	"github.com/findy-network/findy-agent-vault/db/model"
 	"github.com/findy-network/findy-agent-vault/db/store"
+	graph "github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/paginator"
 	"github.com/findy-network/findy-agent-vault/resolver/query/agent"
 	"github.com/findy-network/findy-agent-vault/utils"
 	"github.com/lainio/err2"
 	"github.com/lainio/err2/try"
 )
 
+const (
+	replayBatchSize = 100
+	maxReplayEvents = 1000
+)
+
+var errTooManyEvents = errors.New("too many events to replay, fetch the events and subscribe again")
+
 type Updater struct {
 	db               store.DB
 	eventSubscribers *subscriberRegister
+	recentEvents     *recentEvents
+	// origin identifies this replica in the published events,
+	// events are not published to the other replicas if it is empty
+	origin string
+	// jobTimeouts resolve the expiry of the jobs waiting for the other party
+	jobTimeouts JobTimeouts
 	*agent.Resolver
 }
 
-func NewUpdater(db store.DB, agentResolver *agent.Resolver) *Updater {
+func NewUpdater(db store.DB, agentResolver *agent.Resolver, origin string, jobTimeouts JobTimeouts) *Updater {
 	return &Updater{
 		db,
 		newSubscriberRegister(),
+		newRecentEvents(recentEventCount),
+		origin,
+		jobTimeouts,
 		agentResolver,
 	}
 }
 
+// Close ends the event subscriptions
+func (r *Updater) Close() {
+	r.eventSubscribers.close()
+}
+
+// eventsAfter returns the stored events of the tenant created after the cursor in creation order.
+// Event cursors are sequence numbers, so the events the client has received up to the cursor are not repeated.
+func (r *Updater) eventsAfter(tenantID string, cursor uint64) (edges []*graph.EventEdge, err error) {
+	defer err2.Handle(&err)
+
+	edges = make([]*graph.EventEdge, 0)
+	for hasNextPage := true; hasNextPage; {
+		events, fetchErr := r.db.GetEvents(&paginator.BatchInfo{Count: replayBatchSize, After: cursor}, tenantID, nil)
+		if store.ErrorCode(fetchErr) == store.ErrCodeNotFound {
+			break
+		}
+		try.To(fetchErr)
+
+		if len(edges)+len(events.Events) > maxReplayEvents {
+			return nil, errTooManyEvents
+		}
+		for _, event := range events.Events {
+			edges = append(edges, event.ToEdge())
+		}
+		hasNextPage = events.HasNextPage
+		if len(events.Events) > 0 {
+			cursor = events.Events[len(events.Events)-1].Cursor
+		}
+	}
+	return edges, nil
+}
+
 func (r *Updater) AddEvent(tenantID string, job *model.Job, description string) (err error) {
 	defer err2.Handle(&err)
 	var connectionID, jobID *string
@@ -38,7 +90,8 @@ func (r *Updater) AddEvent(tenantID string, job *model.Job, description string)
 		JobID:        jobID,
 	}))
 
-	r.eventSubscribers.notify(tenantID, event)
+	r.dispatch(tenantID, event)
+	r.publish(tenantID, event)
 	return err
 }
 
@@ -47,6 +100,7 @@ func (r *Updater) AddJob(inputJob *model.Job, description string) (job *model.Jo
 
 	utils.LogMed().Infof("Add job with ID %s for tenant %s", inputJob.ID, inputJob.TenantID)
 
+	inputJob.Expires = r.jobTimeouts.expires(inputJob)
 	job = try.To1(r.db.AddJob(inputJob))
 
 	try.To(r.AddEvent(job.TenantID, job, description))
@@ -59,6 +113,7 @@ func (r *Updater) UpdateJob(job *model.Job, description string) (err error) {
 
 	utils.LogMed().Infof("Update job with ID %s for tenant %s", job.ID, job.TenantID)
 
+	job.Expires = r.jobTimeouts.expires(job)
 	job = try.To1(r.db.UpdateJob(job))
 
 	try.To(r.AddEvent(job.TenantID, job, description))
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
+	"github.com/findy-network/findy-agent-vault/resolver/update"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	agencyTimeout     = "AGENCY_TIMEOUT"
+	agencyCancelled   = "AGENCY_CANCELLED"
+	agencyUnavailable = "AGENCY_UNAVAILABLE"
+	resyncRequired    = "RESYNC_REQUIRED"
+)
+
+var typedErrors = []struct {
+	err  error
+	code string
+}{
+	{agency.ErrTimeout, agencyTimeout},
+	{agency.ErrCancelled, agencyCancelled},
+	{agency.ErrUnavailable, agencyUnavailable},
+	{update.ErrResyncRequired, resyncRequired},
+}
+
+// presentError tags the known error types with an error code
+// and hides the internal error details from the client
+func presentError(ctx context.Context, e error) *gqlerror.Error {
+	err := graphql.DefaultErrorPresenter(ctx, e)
+	for _, typed := range typedErrors {
+		if errors.Is(e, typed.err) {
+			err.Message = typed.err.Error()
+			if err.Extensions == nil {
+				err.Extensions = map[string]interface{}{}
+			}
+			err.Extensions["code"] = typed.code
+			break
+		}
+	}
+	return err
+}
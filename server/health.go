@@ -0,0 +1,32 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/findy-network/findy-agent-vault/graph/model"
+	"github.com/findy-network/findy-agent-vault/utils"
+	"github.com/golang/glog"
+)
+
+// HealthStatusHandler reports the service status as JSON.
+// Status code is 503 when the agency is not available, a degraded agency is reported in the body only.
+func HealthStatusHandler(status func() *model.SystemStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current := status()
+		if utils.LogTrace() {
+			glog.Infof("health status check %s %+v", r.URL.Path, current.Agency)
+		}
+
+		js, err := json.Marshal(current)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !current.Agency.Available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(js)
+	}
+}
@@ -11,11 +11,13 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/findy-network/findy-agent-vault/graph/generated"
+	"github.com/findy-network/findy-agent-vault/resolver/update"
 	"github.com/findy-network/findy-agent-vault/utils"
 	jwtMW "github.com/findy-network/findy-common-go/jwt/mw"
 	"github.com/golang/glog"
 	"github.com/gorilla/websocket"
 	"github.com/rs/cors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 const (
@@ -64,8 +66,21 @@ func NewServer(resolver generated.ResolverRoot, jwtSecret string) *VaultServer {
 		Cache: lru.New(persistedQueryCacheSize),
 	})
 
+	srv.SetErrorPresenter(presentError)
+
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(update.WithDropSignal(ctx))
+	})
+
 	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
 		res := next(ctx)
+		if res == nil {
+			// subscription completes, dropped subscriber is told to resync first
+			if update.TakeDropped(ctx) {
+				return &graphql.Response{Errors: gqlerror.List{presentError(ctx, update.ErrResyncRequired)}}
+			}
+			return nil
+		}
 		for index, err := range res.Errors {
 			glog.Errorf("Returning GQL error %s-%d: %s", err.Path, index, err.Error())
 		}
@@ -3,13 +3,19 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	agency "github.com/findy-network/findy-agent-vault/agency/model"
 	"github.com/findy-network/findy-agent-vault/db/fake"
+	"github.com/findy-network/findy-agent-vault/graph/model"
 	"github.com/findy-network/findy-agent-vault/resolver"
+	"github.com/findy-network/findy-agent-vault/resolver/update"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 const testQuery = "{\n  __schema {\n    queryType {\n      name\n    }\n  }\n}"
@@ -65,3 +71,65 @@ func TestServerForSuccess(t *testing.T) {
 		t.Errorf("Expected response, none found")
 	}
 }
+
+func TestPresentError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code interface{}
+	}{
+		{"timeout", fmt.Errorf("%w: rpc error", agency.ErrTimeout), agencyTimeout},
+		{"cancelled", fmt.Errorf("%w: rpc error", agency.ErrCancelled), agencyCancelled},
+		{"resync", update.ErrResyncRequired, resyncRequired},
+		{"other", errors.New("other error"), nil},
+	}
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			got := presentError(context.TODO(), gqlerror.WrapPath(nil, fmt.Errorf("resolver: %w", tc.err)))
+			if got.Extensions["code"] != tc.code {
+				t.Errorf("Mismatch in error code, expected: %v got: %v", tc.code, got.Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestHealthStatusHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		breaker model.BreakerState
+		code    int
+	}{
+		{"available", model.BreakerStateClosed, http.StatusOK},
+		{"degraded", model.BreakerStateHalfOpen, http.StatusOK},
+		{"unavailable", model.BreakerStateOpen, http.StatusServiceUnavailable},
+	}
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			handler := HealthStatusHandler(func() *model.SystemStatus {
+				return &model.SystemStatus{
+					Version: "test",
+					Agency: &model.AgencyStatus{
+						Available: tc.breaker != model.BreakerStateOpen,
+						Degraded:  tc.breaker != model.BreakerStateClosed,
+						Breaker:   tc.breaker,
+					},
+				}
+			})
+			request, _ := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/health/status", http.NoBody)
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tc.code {
+				t.Errorf("Mismatch in status code, expected: %d got: %d", tc.code, response.Code)
+			}
+			got := &model.SystemStatus{}
+			if err := json.Unmarshal(response.Body.Bytes(), got); err != nil {
+				t.Errorf("Invalid health status response %s", err)
+			} else if got.Agency.Breaker != tc.breaker || got.Agency.Degraded != (tc.breaker != model.BreakerStateClosed) {
+				t.Errorf("Mismatch in breaker state, expected: %s got: %s", tc.breaker, got.Agency.Breaker)
+			}
+		})
+	}
+}
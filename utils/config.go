@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/golang/glog"
 	"github.com/lainio/err2"
@@ -14,6 +15,22 @@ const defaultPort = "8085"
 const defaultAgencyPort = "50051"
 const defaultDBPort = "5432"
 const localhost = "localhost"
+const defaultJobTimeout = 24 * 60 * 60
+const defaultJobSweepInterval = 60
+const defaultOutboxInterval = 5
+const defaultDeadLetterInterval = 30
+const defaultAgencyCallTimeout = 30
+const defaultAgencyBreakerThreshold = 5
+const defaultAgencyBreakerCooldown = 30
+const defaultAgencyKeepaliveTimeout = 90
+const defaultAgencyListenerConcurrency = 10
+const defaultAgencyListenerStartRate = 20
+const defaultAgencyIdleDays = 30
+const defaultShutdownTimeout = 25
+const defaultLeaderElectionInterval = 10
+const defaultProcessedNotificationDays = 30
+const defaultReplicaHeartbeatInterval = 10
+const defaultEventListenerPingInterval = 60
 
 var Version = "dev"
 
@@ -21,27 +38,66 @@ var Version = "dev"
 const defaultJWTSecret = "mySuperSecretKeyLol"
 
 type Configuration struct {
-	// true if this vault service is the main agency subscriber
-	// TODO: this need to be rethought when we are scaling vault
+	// true if this vault service is a candidate for the main agency subscriber,
+	// the subscriber is elected among the candidates
 	AgencyMainSubscriber bool   `mapstructure:"agency_main_subscriber"`
 	AgencyCertPath       string `mapstructure:"agency_cert_path"`
 	AgencyHost           string `mapstructure:"agency_host"`
 	AgencyPort           int    `mapstructure:"agency_port"`
 	AgencyAdminID        string `mapstructure:"agency_admin_id"`
-	AgencyInsecure       bool   `mapstructure:"agency_insecure"`
-	Address              string
-	DBHost               string `mapstructure:"db_host"`
-	DBPassword           string `mapstructure:"db_password"`
-	DBPort               int    `mapstructure:"db_port"`
-	DBTracing            bool   `mapstructure:"db_tracing"`
-	DBMigrationsPath     string `mapstructure:"db_migrations_path"`
-	DBName               string `mapstructure:"db_name"`
-	GenerateFakeData     bool
+	// consecutive connection failures before agency calls fail fast, 0 disables the breaker
+	AgencyBreakerThreshold int `mapstructure:"agency_breaker_threshold"`
+	// seconds to fail fast before trying the agency again
+	AgencyBreakerCooldown int `mapstructure:"agency_breaker_cooldown"`
+	// seconds without keepalive before an agent listener is reconnected, 0 disables the watchdog
+	AgencyKeepaliveTimeout int `mapstructure:"agency_keepalive_timeout"`
+	// max number of agent listeners opened in parallel on startup, 0 means no limit
+	AgencyListenerConcurrency int `mapstructure:"agency_listener_concurrency"`
+	// agent listeners opened per second on startup, 0 means no limit
+	AgencyListenerStartRate int `mapstructure:"agency_listener_start_rate"`
+	// days without access before the agent listener is suspended, 0 keeps all listeners open
+	AgencyIdleDays int `mapstructure:"agency_idle_days"`
+	// deadline in seconds for a single agency call, 0 means no deadline
+	AgencyCallTimeout int  `mapstructure:"agency_call_timeout"`
+	AgencyInsecure    bool `mapstructure:"agency_insecure"`
+	Address           string
+	DBHost            string `mapstructure:"db_host"`
+	DBPassword        string `mapstructure:"db_password"`
+	DBPort            int    `mapstructure:"db_port"`
+	DBTracing         bool   `mapstructure:"db_tracing"`
+	DBMigrationsPath  string `mapstructure:"db_migrations_path"`
+	DBName            string `mapstructure:"db_name"`
+	// interval in seconds for retrying failed notifications, 0 disables retries
+	DeadLetterInterval int `mapstructure:"dead_letter_interval"`
+	// agent ID of the admin managing the dead letters of all tenants, empty disables the admin operations
+	DeadLetterAdminID string `mapstructure:"dead_letter_admin_id"`
+	GenerateFakeData  bool
+	// interval in seconds for checking the connection of the event listener,
+	// 0 disables delivering events between the replicas
+	EventListenerPingInterval int `mapstructure:"event_listener_ping_interval"`
+	// identifies this vault instance between replicas, defaults to host name
+	InstanceID string `mapstructure:"instance_id"`
+	// job timeouts in seconds per protocol, 0 means that jobs do not expire
+	JobTimeoutConnection int    `mapstructure:"job_timeout_connection"`
+	JobTimeoutCredential int    `mapstructure:"job_timeout_credential"`
+	JobTimeoutProof      int    `mapstructure:"job_timeout_proof"`
+	JobSweepInterval     int    `mapstructure:"job_sweep_interval"`
 	JWTKey               string `mapstructure:"jwt_key"`
 	LogLevel             string `mapstructure:"log_level"`
-	ServerPort           int    `mapstructure:"server_port"`
-	UsePlayground        bool   `mapstructure:"use_playground"`
-	Version              string
+	// interval in seconds for electing the main agency subscriber, 0 disables the subscription
+	LeaderElectionInterval int `mapstructure:"leader_election_interval"`
+	// interval in seconds for retrying failed outgoing operations, 0 disables retries
+	OutboxInterval int `mapstructure:"outbox_interval"`
+	// days the processed notification states are kept for skipping replays, 0 keeps them forever
+	ProcessedNotificationDays int  `mapstructure:"processed_notification_days"`
+	ServerPort                int  `mapstructure:"server_port"`
+	UsePlayground             bool `mapstructure:"use_playground"`
+	Version                   string
+	// seconds to wait for in-flight work to complete on shutdown
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+	// interval in seconds for the heartbeat of this replica,
+	// 0 disables sharding the agent listeners between the replicas
+	ReplicaHeartbeatInterval int `mapstructure:"replica_heartbeat_interval"`
 }
 
 func LoadConfig() *Configuration {
@@ -57,16 +113,36 @@ func LoadConfig() *Configuration {
 	v.SetDefault("agency_host", localhost)
 	v.SetDefault("agency_port", defaultAgencyPort)
 	v.SetDefault("agency_admin_id", "findy-root")
+	v.SetDefault("agency_breaker_threshold", defaultAgencyBreakerThreshold)
+	v.SetDefault("agency_breaker_cooldown", defaultAgencyBreakerCooldown)
+	v.SetDefault("agency_call_timeout", defaultAgencyCallTimeout)
 	v.SetDefault("agency_insecure", false)
+	v.SetDefault("agency_keepalive_timeout", defaultAgencyKeepaliveTimeout)
+	v.SetDefault("agency_listener_concurrency", defaultAgencyListenerConcurrency)
+	v.SetDefault("agency_listener_start_rate", defaultAgencyListenerStartRate)
+	v.SetDefault("agency_idle_days", defaultAgencyIdleDays)
 	v.SetDefault("db_host", localhost)
 	v.SetDefault("db_password", "")
 	v.SetDefault("db_port", defaultDBPort)
 	v.SetDefault("db_tracing", false)
 	v.SetDefault("db_migrations_path", "file://db/migrations")
 	v.SetDefault("db_name", "vault")
+	v.SetDefault("dead_letter_interval", defaultDeadLetterInterval)
+	v.SetDefault("dead_letter_admin_id", "")
+	v.SetDefault("event_listener_ping_interval", defaultEventListenerPingInterval)
+	v.SetDefault("job_timeout_connection", defaultJobTimeout)
+	v.SetDefault("job_timeout_credential", defaultJobTimeout)
+	v.SetDefault("job_timeout_proof", defaultJobTimeout)
+	v.SetDefault("job_sweep_interval", defaultJobSweepInterval)
+	v.SetDefault("instance_id", "")
 	v.SetDefault("jwt_key", defaultJWTSecret)
+	v.SetDefault("leader_election_interval", defaultLeaderElectionInterval)
 	v.SetDefault("log_level", "3")
+	v.SetDefault("outbox_interval", defaultOutboxInterval)
+	v.SetDefault("processed_notification_days", defaultProcessedNotificationDays)
+	v.SetDefault("replica_heartbeat_interval", defaultReplicaHeartbeatInterval)
 	v.SetDefault("server_port", defaultPort)
+	v.SetDefault("shutdown_timeout", defaultShutdownTimeout)
 	v.SetDefault("use_playground", false)
 
 	viper.SetConfigName("config.yaml")
@@ -85,6 +161,9 @@ func LoadConfig() *Configuration {
 	config.Address = fmt.Sprintf(":%d", config.ServerPort)
 	SetLogConfig(&config)
 	config.Version = Version
+	if config.InstanceID == "" {
+		config.InstanceID, _ = os.Hostname()
+	}
 
 	// make sure we do not accidentally subscribe to the data pump when developing in local
 	if config.AgencyMainSubscriber && config.AgencyHost != localhost && config.DBHost == localhost {
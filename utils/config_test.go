@@ -33,6 +33,7 @@ func TestConfigFromEnv(t *testing.T) {
 	t.Setenv("FAV_AGENCY_ADMIN_ID", testSecret)
 	t.Setenv("FAV_AGENCY_CERT_PATH", testPath)
 	t.Setenv("FAV_AGENCY_INSECURE", testInsecure)
+	t.Setenv("FAV_JOB_TIMEOUT_PROOF", strPort)
 
 	config := LoadConfig()
 	assert.Equal(config.ServerPort, testPort, "config port differs")
@@ -49,4 +50,6 @@ func TestConfigFromEnv(t *testing.T) {
 	assert.Equal(config.AgencyCertPath, testPath, "agency cert path differs")
 	assert.Equal(config.AgencyCertPath, testPath, "agency cert path differs")
 	assert.That(config.AgencyInsecure, "agency insecure differs")
+	assert.Equal(config.JobTimeoutProof, testPort, "job timeout for proof differs")
+	assert.Equal(config.JobTimeoutConnection, defaultJobTimeout, "job timeout for connection differs")
 }
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// Wait waits for the group to finish or the context to be done
+func Wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}